@@ -0,0 +1,407 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0-devel
+// 	protoc        (unknown)
+// source: parca/otlp/collector/profiles/v1development/profiles_service.proto
+
+// Vendored from opentelemetry-proto with the package renamed, so that it does
+// not conflict with the OpenTelemetry protos of go.opentelemetry.io/proto/otlp,
+// the version of which in use does not include the profiles.
+
+package profilesv1development
+
+import (
+	v1development "github.com/parca-dev/parca/gen/proto/go/parca/otlp/profiles/v1development"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportProfilesServiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// An array of ResourceProfiles.
+	// For data coming from a single resource this array will typically contain one
+	// element. Intermediary nodes (such as OpenTelemetry Collector) that receive
+	// data from multiple origins typically batch the data before forwarding further and
+	// in that case this array will contain multiple elements.
+	ResourceProfiles []*v1development.ResourceProfiles `protobuf:"bytes,1,rep,name=resource_profiles,json=resourceProfiles,proto3" json:"resource_profiles,omitempty"`
+	// The reference table containing all data shared by profiles across the message being sent.
+	Dictionary *v1development.ProfilesDictionary `protobuf:"bytes,2,opt,name=dictionary,proto3" json:"dictionary,omitempty"`
+}
+
+func (x *ExportProfilesServiceRequest) Reset() {
+	*x = ExportProfilesServiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportProfilesServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProfilesServiceRequest) ProtoMessage() {}
+
+func (x *ExportProfilesServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProfilesServiceRequest.ProtoReflect.Descriptor instead.
+func (*ExportProfilesServiceRequest) Descriptor() ([]byte, []int) {
+	return file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportProfilesServiceRequest) GetResourceProfiles() []*v1development.ResourceProfiles {
+	if x != nil {
+		return x.ResourceProfiles
+	}
+	return nil
+}
+
+func (x *ExportProfilesServiceRequest) GetDictionary() *v1development.ProfilesDictionary {
+	if x != nil {
+		return x.Dictionary
+	}
+	return nil
+}
+
+type ExportProfilesServiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The details of a partially successful export request.
+	//
+	// If the request is only partially accepted
+	// (i.e. when the server accepts only parts of the data and rejects the rest)
+	// the server MUST initialize the `partial_success` field and MUST
+	// set the `rejected_<signal>` with the number of items it rejected.
+	//
+	// Servers MAY also make use of the `partial_success` field to convey
+	// warnings/suggestions to senders even when the request was fully accepted.
+	// In such cases, the `rejected_<signal>` MUST have a value of `0` and
+	// the `error_message` MUST be non-empty.
+	//
+	// A `partial_success` message with an empty value (rejected_<signal> = 0 and
+	// `error_message` = "") is equivalent to it not being set/present. Senders
+	// SHOULD interpret it the same way as in the full success case.
+	PartialSuccess *ExportProfilesPartialSuccess `protobuf:"bytes,1,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+}
+
+func (x *ExportProfilesServiceResponse) Reset() {
+	*x = ExportProfilesServiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportProfilesServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProfilesServiceResponse) ProtoMessage() {}
+
+func (x *ExportProfilesServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProfilesServiceResponse.ProtoReflect.Descriptor instead.
+func (*ExportProfilesServiceResponse) Descriptor() ([]byte, []int) {
+	return file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportProfilesServiceResponse) GetPartialSuccess() *ExportProfilesPartialSuccess {
+	if x != nil {
+		return x.PartialSuccess
+	}
+	return nil
+}
+
+type ExportProfilesPartialSuccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of rejected profiles.
+	//
+	// A `rejected_<signal>` field holding a `0` value indicates that the
+	// request was fully accepted.
+	RejectedProfiles int64 `protobuf:"varint,1,opt,name=rejected_profiles,json=rejectedProfiles,proto3" json:"rejected_profiles,omitempty"`
+	// A developer-facing human-readable message in English. It should be used
+	// either to explain why the server rejected parts of the data during a partial
+	// success or to convey warnings/suggestions during a full success. The message
+	// should offer guidance on how users can address such issues.
+	//
+	// error_message is an optional field. An error_message with an empty value
+	// is equivalent to it not being set.
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *ExportProfilesPartialSuccess) Reset() {
+	*x = ExportProfilesPartialSuccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportProfilesPartialSuccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProfilesPartialSuccess) ProtoMessage() {}
+
+func (x *ExportProfilesPartialSuccess) ProtoReflect() protoreflect.Message {
+	mi := &file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProfilesPartialSuccess.ProtoReflect.Descriptor instead.
+func (*ExportProfilesPartialSuccess) Descriptor() ([]byte, []int) {
+	return file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExportProfilesPartialSuccess) GetRejectedProfiles() int64 {
+	if x != nil {
+		return x.RejectedProfiles
+	}
+	return 0
+}
+
+func (x *ExportProfilesPartialSuccess) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+var File_parca_otlp_collector_profiles_v1development_profiles_service_proto protoreflect.FileDescriptor
+
+var file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDesc = []byte{
+	0x0a, 0x42, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2f, 0x6f, 0x74, 0x6c, 0x70, 0x2f, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2f,
+	0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2b, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2e, 0x6f, 0x74, 0x6c, 0x70,
+	0x2e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x1a, 0x30, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2f, 0x6f, 0x74, 0x6c, 0x70, 0x2f, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70,
+	0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xd7, 0x01, 0x0a, 0x1c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2e, 0x6f, 0x74, 0x6c, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d,
+	0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x55, 0x0a, 0x0a, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x61, 0x72,
+	0x63, 0x61, 0x2e, 0x6f, 0x74, 0x6c, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x44, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72,
+	0x79, 0x52, 0x0a, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x22, 0x93, 0x01,
+	0x0a, 0x1d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x72, 0x0a, 0x0f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x49, 0x2e, 0x70, 0x61, 0x72, 0x63, 0x61,
+	0x2e, 0x6f, 0x74, 0x6c, 0x70, 0x2e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x22, 0x70, 0x0a, 0x1c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xb5, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x06, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x49, 0x2e, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2e, 0x6f, 0x74, 0x6c,
+	0x70, 0x2e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65,
+	0x6e, 0x74, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x4a, 0x2e, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2e, 0x6f, 0x74, 0x6c, 0x70, 0x2e, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x84, 0x03,
+	0x0a, 0x2f, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2e, 0x6f, 0x74, 0x6c, 0x70,
+	0x2e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x42, 0x15, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x69, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2d, 0x64, 0x65, 0x76,
+	0x2f, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x61, 0x72, 0x63, 0x61, 0x2f, 0x6f, 0x74, 0x6c, 0x70, 0x2f, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x2f, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x3b,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x76, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0xa2, 0x02, 0x04, 0x50, 0x4f, 0x43, 0x50, 0xaa, 0x02, 0x2b, 0x50,
+	0x61, 0x72, 0x63, 0x61, 0x2e, 0x4f, 0x74, 0x6c, 0x70, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2e, 0x56, 0x31, 0x64,
+	0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0xca, 0x02, 0x2b, 0x50, 0x61, 0x72,
+	0x63, 0x61, 0x5c, 0x4f, 0x74, 0x6c, 0x70, 0x5c, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x5c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5c, 0x56, 0x31, 0x64, 0x65, 0x76,
+	0x65, 0x6c, 0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0xe2, 0x02, 0x37, 0x50, 0x61, 0x72, 0x63, 0x61,
+	0x5c, 0x4f, 0x74, 0x6c, 0x70, 0x5c, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5c,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5c, 0x56, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c,
+	0x6f, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0xea, 0x02, 0x2f, 0x50, 0x61, 0x72, 0x63, 0x61, 0x3a, 0x3a, 0x4f, 0x74, 0x6c, 0x70,
+	0x3a, 0x3a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x3a, 0x3a, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x3a, 0x3a, 0x56, 0x31, 0x64, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70,
+	0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescOnce sync.Once
+	file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescData = file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDesc
+)
+
+func file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescGZIP() []byte {
+	file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescOnce.Do(func() {
+		file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescData)
+	})
+	return file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDescData
+}
+
+var file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_parca_otlp_collector_profiles_v1development_profiles_service_proto_goTypes = []interface{}{
+	(*ExportProfilesServiceRequest)(nil),     // 0: parca.otlp.collector.profiles.v1development.ExportProfilesServiceRequest
+	(*ExportProfilesServiceResponse)(nil),    // 1: parca.otlp.collector.profiles.v1development.ExportProfilesServiceResponse
+	(*ExportProfilesPartialSuccess)(nil),     // 2: parca.otlp.collector.profiles.v1development.ExportProfilesPartialSuccess
+	(*v1development.ResourceProfiles)(nil),   // 3: parca.otlp.profiles.v1development.ResourceProfiles
+	(*v1development.ProfilesDictionary)(nil), // 4: parca.otlp.profiles.v1development.ProfilesDictionary
+}
+var file_parca_otlp_collector_profiles_v1development_profiles_service_proto_depIdxs = []int32{
+	3, // 0: parca.otlp.collector.profiles.v1development.ExportProfilesServiceRequest.resource_profiles:type_name -> parca.otlp.profiles.v1development.ResourceProfiles
+	4, // 1: parca.otlp.collector.profiles.v1development.ExportProfilesServiceRequest.dictionary:type_name -> parca.otlp.profiles.v1development.ProfilesDictionary
+	2, // 2: parca.otlp.collector.profiles.v1development.ExportProfilesServiceResponse.partial_success:type_name -> parca.otlp.collector.profiles.v1development.ExportProfilesPartialSuccess
+	0, // 3: parca.otlp.collector.profiles.v1development.ProfilesService.Export:input_type -> parca.otlp.collector.profiles.v1development.ExportProfilesServiceRequest
+	1, // 4: parca.otlp.collector.profiles.v1development.ProfilesService.Export:output_type -> parca.otlp.collector.profiles.v1development.ExportProfilesServiceResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_parca_otlp_collector_profiles_v1development_profiles_service_proto_init() }
+func file_parca_otlp_collector_profiles_v1development_profiles_service_proto_init() {
+	if File_parca_otlp_collector_profiles_v1development_profiles_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportProfilesServiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportProfilesServiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportProfilesPartialSuccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_parca_otlp_collector_profiles_v1development_profiles_service_proto_goTypes,
+		DependencyIndexes: file_parca_otlp_collector_profiles_v1development_profiles_service_proto_depIdxs,
+		MessageInfos:      file_parca_otlp_collector_profiles_v1development_profiles_service_proto_msgTypes,
+	}.Build()
+	File_parca_otlp_collector_profiles_v1development_profiles_service_proto = out.File
+	file_parca_otlp_collector_profiles_v1development_profiles_service_proto_rawDesc = nil
+	file_parca_otlp_collector_profiles_v1development_profiles_service_proto_goTypes = nil
+	file_parca_otlp_collector_profiles_v1development_profiles_service_proto_depIdxs = nil
+}
@@ -16,8 +16,11 @@ package queryv1alpha1
 
 import (
 	"fmt"
+	"regexp"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -27,6 +30,17 @@ func (r *QueryRangeRequest) Validate() error {
 		validation.Field(&r.Start, validation.Required),
 		validation.Field(&r.End, validation.Required, isAfter(r.Start)),
 		validation.Field(&r.Query, validation.Required),
+		validation.Field(&r.Step, isNonNegative()),
+		validation.Field(&r.GroupBy, validation.Each(isLabelName())),
+		validation.Field(&r.Aggregation, isAggregation()),
+	)
+}
+
+// Validate the SeriesRequest.
+func (r *SeriesRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Start, validation.Required),
+		validation.Field(&r.End, validation.Required, isAfter(r.Start)),
 	)
 }
 
@@ -46,11 +60,32 @@ func (r *QueryRequest) Validate() error {
 			&r.ReportType,
 			isReportType(),
 		),
+		validation.Field(
+			&r.NodeTrimThreshold,
+			validation.Min(float32(0)),
+			validation.Max(float32(1)),
+		),
+		validation.Field(&r.Focus, isRegexp()),
+		validation.Field(&r.Ignore, isRegexp()),
+		validation.Field(&r.Hide, isRegexp()),
+		validation.Field(&r.Show, isRegexp()),
 	)
 	if err != nil {
 		return err
 	}
 
+	switch r.ReportType {
+	case QueryRequest_REPORT_TYPE_SOURCE, QueryRequest_REPORT_TYPE_DISASSEMBLY:
+		err := validateSourceReference(r.GetSourceReference())
+		if err != nil {
+			return err
+		}
+	case QueryRequest_REPORT_TYPE_SANDWICH:
+		if r.GetSourceReference().GetFunction() == "" {
+			return fmt.Errorf("source reference must set a function")
+		}
+	}
+
 	switch r.Mode {
 	case QueryRequest_MODE_SINGLE_UNSPECIFIED:
 		err := validateSingle(r.GetSingle())
@@ -85,6 +120,14 @@ func validateSingle(single *SingleProfile) error {
 	)
 }
 
+func validateSourceReference(ref *SourceReference) error {
+	if ref.GetFunction() == "" && ref.GetFilename() == "" {
+		return fmt.Errorf("source reference must set a function or filename")
+	}
+
+	return nil
+}
+
 func validateMerge(merge *MergeProfile) error {
 	if merge == nil {
 		return fmt.Errorf("merge must not be unset")
@@ -308,3 +351,74 @@ func (a AfterRule) Validate(t interface{}) error {
 
 	return nil
 }
+
+type AggregationRule struct{}
+
+func isAggregation() AggregationRule { return AggregationRule{} }
+
+func (r AggregationRule) Validate(v interface{}) error {
+	i, ok := v.(QueryRangeRequest_Aggregation)
+	if !ok {
+		return fmt.Errorf("aggregation is not a query range aggregation")
+	}
+
+	_, ok = QueryRangeRequest_Aggregation_name[int32(i)]
+	if !ok {
+		return fmt.Errorf("invalid aggregation")
+	}
+
+	return nil
+}
+
+type LabelNameRule struct{}
+
+func isLabelName() LabelNameRule { return LabelNameRule{} }
+
+func (r LabelNameRule) Validate(v interface{}) error {
+	name, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("label name is not a string")
+	}
+
+	if !model.LabelName(name).IsValid() {
+		return fmt.Errorf("invalid label name %q", name)
+	}
+
+	return nil
+}
+
+func isNonNegative() NonNegativeRule { return NonNegativeRule{} }
+
+// NonNegativeRule validates that the duration is not negative.
+type NonNegativeRule struct{}
+
+// Validate runs the validation function for the NonNegativeRule.
+func (r NonNegativeRule) Validate(v interface{}) error {
+	d, ok := v.(*durationpb.Duration)
+	if !ok {
+		return fmt.Errorf("not a duration")
+	}
+
+	if d != nil && d.AsDuration() < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+
+	return nil
+}
+
+type RegexpRule struct{}
+
+func isRegexp() RegexpRule { return RegexpRule{} }
+
+func (r RegexpRule) Validate(v interface{}) error {
+	expr, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("regular expression is not a string")
+	}
+
+	if _, err := regexp.Compile(expr); err != nil {
+		return fmt.Errorf("invalid regular expression: %w", err)
+	}
+
+	return nil
+}
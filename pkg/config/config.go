@@ -32,6 +32,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/parca-dev/parca/pkg/debuginfo"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 const (
@@ -146,6 +147,8 @@ type ScrapeConfig struct {
 	ScrapeTimeout model.Duration `yaml:"scrape_timeout,omitempty"`
 	// The URL scheme with which to fetch metrics from targets.
 	Scheme string `yaml:"scheme,omitempty"`
+	// The tenant to store the scraped profiles for.
+	Tenant string `yaml:"tenant,omitempty"`
 
 	ProfilingConfig *ProfilingConfig `yaml:"profiling_config,omitempty"`
 
@@ -219,6 +222,10 @@ func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return errors.New("job_name is empty")
 	}
 
+	if err := tenant.Validate(c.Tenant); err != nil {
+		return err
+	}
+
 	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
 	// We cannot make it a pointer as the parser panics for inlined pointer structs.
 	// Thus we just do its validation here.
@@ -0,0 +1,330 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jfr parses Java Flight Recorder recordings, extracting their
+// execution samples, allocation samples and lock contention events along
+// with the stack traces they were recorded at.
+package jfr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	chunkHeaderSize = 68
+
+	featureCompressedInts = 1
+
+	supportedMajorVersion = 2
+)
+
+var chunkMagic = []byte("FLR\x00")
+
+// ErrInvalidRecording is returned when a recording cannot be parsed.
+var ErrInvalidRecording = errors.New("invalid jfr recording")
+
+// Frame is a frame of a stack trace.
+type Frame struct {
+	// Function is the fully qualified name of the method, e.g.
+	// java.lang.Thread.run.
+	Function string
+	Line     int64
+}
+
+// Sample is a value recorded at a stack trace.
+type Sample struct {
+	// Stack is the index of the stack trace in the stacks of the recording.
+	Stack int
+	Value int64
+}
+
+// Recording holds the samples of a recording. Samples of the same stack
+// trace are not merged.
+type Recording struct {
+	// Start is the start of the recording in nanoseconds since the epoch.
+	Start int64
+	// Duration is the duration of the recording in nanoseconds.
+	Duration int64
+	// CPUPeriod is the period of the execution sampler in nanoseconds, or 0
+	// if the recording does not include its setting.
+	CPUPeriod int64
+
+	// Stacks are the stack traces of the samples, leaf first.
+	Stacks [][]Frame
+
+	// ExecutionSamples are samples of running threads, each with a value of
+	// 1.
+	ExecutionSamples []Sample
+	// AllocationSamples are samples of allocations, valued by their size in
+	// bytes. They are the jdk.ObjectAllocationSample events of the recording,
+	// or if it has none, its TLAB events, as both sample the same allocations.
+	AllocationSamples []Sample
+	// LockSamples are samples of threads contending for a monitor, valued by
+	// the duration they were blocked for in nanoseconds. Parked threads are
+	// not included, as they mostly wait for work rather than for a lock.
+	LockSamples []Sample
+}
+
+type chunkHeader struct {
+	major, minor       uint16
+	size               int64
+	constantPoolOffset int64
+	metadataOffset     int64
+	startNanos         int64
+	durationNanos      int64
+	startTicks         int64
+	ticksPerSecond     int64
+	features           int32
+}
+
+func parseChunkHeader(b []byte) (chunkHeader, error) {
+	if len(b) < chunkHeaderSize {
+		return chunkHeader{}, errors.New("truncated chunk header")
+	}
+	if !bytes.Equal(b[:4], chunkMagic) {
+		return chunkHeader{}, errors.New("invalid chunk magic")
+	}
+
+	i64 := func(off int) int64 { return int64(binary.BigEndian.Uint64(b[off:])) }
+	h := chunkHeader{
+		major:              binary.BigEndian.Uint16(b[4:]),
+		minor:              binary.BigEndian.Uint16(b[6:]),
+		size:               i64(8),
+		constantPoolOffset: i64(16),
+		metadataOffset:     i64(24),
+		startNanos:         i64(32),
+		durationNanos:      i64(40),
+		startTicks:         i64(48),
+		ticksPerSecond:     i64(56),
+		features:           int32(binary.BigEndian.Uint32(b[64:])),
+	}
+	if h.major != supportedMajorVersion {
+		return chunkHeader{}, fmt.Errorf("unsupported version %d.%d", h.major, h.minor)
+	}
+	if h.size < chunkHeaderSize || h.size > int64(len(b)) {
+		return chunkHeader{}, fmt.Errorf("invalid chunk size %d", h.size)
+	}
+	if h.ticksPerSecond <= 0 {
+		return chunkHeader{}, fmt.Errorf("invalid ticks per second %d", h.ticksPerSecond)
+	}
+
+	return h, nil
+}
+
+// Parse parses the recording, made of one or more chunks. Events other than
+// samples are skipped.
+func Parse(b []byte) (*Recording, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("%w: empty recording", ErrInvalidRecording)
+	}
+
+	rec := &Recording{}
+	tlabSamples := []Sample{}
+	var end int64
+	for chunk := 0; len(b) > 0; chunk++ {
+		h, err := parseChunkHeader(b)
+		if err != nil {
+			return nil, fmt.Errorf("%w: chunk %d: %v", ErrInvalidRecording, chunk, err)
+		}
+
+		if chunk == 0 || h.startNanos < rec.Start {
+			rec.Start = h.startNanos
+		}
+		if e := h.startNanos + h.durationNanos; e > end {
+			end = e
+		}
+
+		p := newChunkParser(b[:h.size], h)
+		if err := p.parse(rec, &tlabSamples); err != nil {
+			return nil, fmt.Errorf("%w: chunk %d: %v", ErrInvalidRecording, chunk, err)
+		}
+
+		b = b[h.size:]
+	}
+	rec.Duration = end - rec.Start
+	if len(rec.AllocationSamples) == 0 {
+		rec.AllocationSamples = tlabSamples
+	}
+
+	return rec, nil
+}
+
+// parse reads the metadata and constant pools of the chunk, then adds the
+// samples of its events to the recording. Samples of the TLAB events are added
+// to the TLAB samples instead.
+func (p *chunkParser) parse(rec *Recording, tlabSamples *[]Sample) error {
+	if err := p.readMetadata(); err != nil {
+		return err
+	}
+	if err := p.readConstantPools(); err != nil {
+		return err
+	}
+
+	// Stack traces of the constant pool are shared by many events.
+	stacks := map[int64]int{}
+	stack := func(o *object) int {
+		r, isRef := o.raw("stackTrace").(ref)
+		if isRef {
+			if i, ok := stacks[r.key]; ok {
+				return i
+			}
+		}
+
+		i := len(rec.Stacks)
+		rec.Stacks = append(rec.Stacks, p.frames(p.object(o, "stackTrace")))
+		if isRef {
+			stacks[r.key] = i
+		}
+		return i
+	}
+
+	for offset := int64(chunkHeaderSize); offset < int64(len(p.r.b)); {
+		p.r.pos = int(offset)
+		size, typ, err := p.readEventHeader()
+		if err != nil {
+			return fmt.Errorf("event at offset %d: %w", offset, err)
+		}
+		offset += size
+
+		c, ok := p.classes[typ]
+		if !ok || !isSampleEvent(c.name) {
+			continue
+		}
+		v, err := p.readValue(c, 0)
+		if err != nil {
+			return fmt.Errorf("event %s at offset %d: %w", c.name, offset-size, err)
+		}
+		o, ok := v.(*object)
+		if !ok {
+			continue
+		}
+
+		switch c.name {
+		case "jdk.ExecutionSample":
+			rec.ExecutionSamples = append(rec.ExecutionSamples, Sample{Stack: stack(o), Value: 1})
+		case "jdk.ObjectAllocationInNewTLAB":
+			*tlabSamples = append(*tlabSamples, Sample{Stack: stack(o), Value: p.long(o, "tlabSize")})
+		case "jdk.ObjectAllocationOutsideTLAB":
+			*tlabSamples = append(*tlabSamples, Sample{Stack: stack(o), Value: p.long(o, "allocationSize")})
+		case "jdk.ObjectAllocationSample":
+			rec.AllocationSamples = append(rec.AllocationSamples, Sample{Stack: stack(o), Value: p.long(o, "weight")})
+		case "jdk.JavaMonitorEnter":
+			rec.LockSamples = append(rec.LockSamples, Sample{Stack: stack(o), Value: p.nanos(p.long(o, "duration"))})
+		case "jdk.ActiveSetting":
+			if sc, ok := p.classes[p.long(o, "id")]; ok && sc.name == "jdk.ExecutionSample" && p.string(o, "name") == "period" {
+				if period := parsePeriod(p.string(o, "value")); period > 0 {
+					rec.CPUPeriod = period
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func isSampleEvent(name string) bool {
+	switch name {
+	case "jdk.ExecutionSample",
+		"jdk.ObjectAllocationInNewTLAB",
+		"jdk.ObjectAllocationOutsideTLAB",
+		"jdk.ObjectAllocationSample",
+		"jdk.JavaMonitorEnter",
+		"jdk.ActiveSetting":
+		return true
+	default:
+		return false
+	}
+}
+
+// frames returns the frames of the stack trace, leaf first, like they are
+// recorded.
+func (p *chunkParser) frames(st *object) []Frame {
+	if st == nil {
+		return nil
+	}
+
+	values, _ := st.raw("frames").([]interface{})
+	frames := make([]Frame, 0, len(values))
+	for _, v := range values {
+		f, ok := p.resolve(v).(*object)
+		if !ok {
+			continue
+		}
+
+		function := "unknown"
+		if m := p.object(f, "method"); m != nil {
+			function = p.string(m, "name")
+			if c := p.object(m, "type"); c != nil {
+				if name := p.string(c, "name"); name != "" {
+					function = strings.ReplaceAll(name, "/", ".") + "." + function
+				}
+			}
+		}
+
+		frames = append(frames, Frame{
+			Function: function,
+			Line:     p.long(f, "lineNumber"),
+		})
+	}
+
+	return frames
+}
+
+// nanos converts ticks of the chunk to nanoseconds.
+func (p *chunkParser) nanos(ticks int64) int64 {
+	tps := p.header.ticksPerSecond
+	return ticks/tps*int64(time.Second) + ticks%tps*int64(time.Second)/tps
+}
+
+// parsePeriod parses the period setting of a sampler, e.g. 20 ms, returning
+// 0 if it is not a duration.
+func parsePeriod(s string) int64 {
+	value, unit, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return 0
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v <= 0 {
+		return 0
+	}
+
+	var d time.Duration
+	switch unit {
+	case "ns":
+		d = time.Nanosecond
+	case "us":
+		d = time.Microsecond
+	case "ms":
+		d = time.Millisecond
+	case "s":
+		d = time.Second
+	case "m":
+		d = time.Minute
+	case "h":
+		d = time.Hour
+	default:
+		return 0
+	}
+	if v > math.MaxInt64/int64(d) {
+		return 0
+	}
+
+	return v * int64(d)
+}
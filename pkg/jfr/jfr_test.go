@@ -0,0 +1,103 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jfr_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/parca-dev/parca/pkg/jfr"
+	"github.com/parca-dev/parca/pkg/jfr/jfrtest"
+)
+
+func TestParse(t *testing.T) {
+	run := []jfr.Frame{
+		{Function: "com.example.Worker.work", Line: 42},
+		{Function: "java.lang.Thread.run", Line: 833},
+	}
+	alloc := []jfr.Frame{
+		{Function: "java.util.ArrayList.grow", Line: 237},
+		{Function: "com.example.Worker.work", Line: 40},
+		{Function: "java.lang.Thread.run", Line: 833},
+	}
+
+	start := time.Unix(1656000000, 0)
+	b := jfrtest.NewRecording(start, 10*time.Second, "20 ms", []jfrtest.Event{
+		{Type: jfrtest.ExecutionSample, Stack: run},
+		{Type: jfrtest.ExecutionSample, Stack: run},
+		{Type: jfrtest.AllocationSample, Stack: alloc, Value: 4096},
+		// The allocation samples of the TLAB events are not counted twice.
+		{Type: jfrtest.AllocationInNewTLAB, Stack: alloc, Value: 8192},
+		{Type: jfrtest.MonitorEnter, Stack: run, Value: int64(3 * time.Millisecond)},
+		// Parked threads are not contending for a lock.
+		{Type: jfrtest.ThreadPark, Stack: run, Value: int64(time.Second)},
+	})
+
+	// Recordings made of several chunks are concatenated chunks.
+	rec, err := jfr.Parse(append(b, b...))
+	require.NoError(t, err)
+
+	require.Equal(t, start.UnixNano(), rec.Start)
+	require.Equal(t, int64(10*time.Second), rec.Duration)
+	require.Equal(t, int64(20*time.Millisecond), rec.CPUPeriod)
+
+	// Stack traces are shared within a chunk.
+	require.Len(t, rec.Stacks, 4)
+	require.Equal(t, run, rec.Stacks[0])
+	require.Equal(t, alloc, rec.Stacks[1])
+
+	require.Equal(t, []jfr.Sample{{Stack: 0, Value: 1}, {Stack: 0, Value: 1}, {Stack: 2, Value: 1}, {Stack: 2, Value: 1}}, rec.ExecutionSamples)
+	require.Equal(t, []jfr.Sample{{Stack: 1, Value: 4096}, {Stack: 3, Value: 4096}}, rec.AllocationSamples)
+	require.Equal(t, []jfr.Sample{{Stack: 0, Value: int64(3 * time.Millisecond)}, {Stack: 2, Value: int64(3 * time.Millisecond)}}, rec.LockSamples)
+}
+
+func TestParse_TLABAllocations(t *testing.T) {
+	alloc := []jfr.Frame{
+		{Function: "java.util.ArrayList.grow", Line: 237},
+		{Function: "java.lang.Thread.run", Line: 833},
+	}
+
+	// Recordings without allocation samples, like those of JDK 11, are
+	// sampled by the TLAB events.
+	rec, err := jfr.Parse(jfrtest.NewRecording(time.Unix(0, 0), time.Second, "", []jfrtest.Event{
+		{Type: jfrtest.AllocationInNewTLAB, Stack: alloc, Value: 8192},
+		{Type: jfrtest.AllocationInNewTLAB, Stack: alloc, Value: 16384},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []jfr.Sample{{Stack: 0, Value: 8192}, {Stack: 0, Value: 16384}}, rec.AllocationSamples)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	b := jfrtest.NewRecording(time.Unix(0, 0), time.Second, "", []jfrtest.Event{
+		{Type: jfrtest.ExecutionSample, Stack: []jfr.Frame{{Function: "main"}}},
+	})
+
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"magic":     []byte("not a recording, certainly not one of a JVM, long enough for a header"),
+		"truncated": b[:len(b)-1],
+		// Objects of the class are nested endlessly without reading a byte.
+		"self nested": jfrtest.NewRecording(time.Unix(0, 0), time.Second, "", []jfrtest.Event{
+			{Type: jfrtest.SelfNested},
+		}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := jfr.Parse(data)
+			require.True(t, errors.Is(err, jfr.ErrInvalidRecording), err)
+		})
+	}
+}
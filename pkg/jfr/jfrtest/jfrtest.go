@@ -0,0 +1,435 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jfrtest writes minimal JFR recordings for tests.
+package jfrtest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parca-dev/parca/pkg/jfr"
+)
+
+// Event types written by NewRecording.
+const (
+	ExecutionSample     = "jdk.ExecutionSample"
+	AllocationSample    = "jdk.ObjectAllocationSample"
+	AllocationInNewTLAB = "jdk.ObjectAllocationInNewTLAB"
+	MonitorEnter        = "jdk.JavaMonitorEnter"
+	ThreadPark          = "jdk.ThreadPark"
+	// SelfNested is a malformed class with an inline field of its own class.
+	// Its events are written to its constant pool instead of as events.
+	SelfNested = "jfrtest.SelfNested"
+)
+
+// Event is an event of a recording. Its value is the allocated bytes of
+// allocation samples, the size of the TLAB of allocations in new TLABs and the
+// duration in nanoseconds of monitor enters and thread parks, and ignored for
+// execution samples.
+type Event struct {
+	Type string
+	// Stack are the frames of the stack trace of the event, leaf first.
+	Stack []jfr.Frame
+	Value int64
+}
+
+const (
+	classBoolean = iota + 1
+	classInt
+	classLong
+	classString
+	classSymbol
+	classClass
+	classMethod
+	classStackFrame
+	classStackTrace
+	classExecutionSample
+	classAllocationSample
+	classAllocationInNewTLAB
+	classMonitorEnter
+	classThreadPark
+	classSelfNested
+	classActiveSetting
+)
+
+type fieldDesc struct {
+	name         string
+	class        int
+	constantPool bool
+	array        bool
+}
+
+type classDesc struct {
+	id     int
+	name   string
+	fields []fieldDesc
+}
+
+var classes = []classDesc{
+	{id: classBoolean, name: "boolean"},
+	{id: classInt, name: "int"},
+	{id: classLong, name: "long"},
+	{id: classString, name: "java.lang.String"},
+	{id: classSymbol, name: "jdk.types.Symbol", fields: []fieldDesc{
+		{name: "string", class: classString},
+	}},
+	{id: classClass, name: "java.lang.Class", fields: []fieldDesc{
+		{name: "name", class: classSymbol, constantPool: true},
+	}},
+	{id: classMethod, name: "jdk.types.Method", fields: []fieldDesc{
+		{name: "type", class: classClass, constantPool: true},
+		{name: "name", class: classSymbol, constantPool: true},
+	}},
+	{id: classStackFrame, name: "jdk.types.StackFrame", fields: []fieldDesc{
+		{name: "method", class: classMethod, constantPool: true},
+		{name: "lineNumber", class: classInt},
+		{name: "bytecodeIndex", class: classInt},
+	}},
+	{id: classStackTrace, name: "jdk.types.StackTrace", fields: []fieldDesc{
+		{name: "truncated", class: classBoolean},
+		{name: "frames", class: classStackFrame, array: true},
+	}},
+	{id: classExecutionSample, name: ExecutionSample, fields: []fieldDesc{
+		{name: "startTime", class: classLong},
+		{name: "stackTrace", class: classStackTrace, constantPool: true},
+	}},
+	{id: classAllocationSample, name: AllocationSample, fields: []fieldDesc{
+		{name: "startTime", class: classLong},
+		{name: "stackTrace", class: classStackTrace, constantPool: true},
+		{name: "weight", class: classLong},
+	}},
+	{id: classAllocationInNewTLAB, name: AllocationInNewTLAB, fields: []fieldDesc{
+		{name: "startTime", class: classLong},
+		{name: "stackTrace", class: classStackTrace, constantPool: true},
+		{name: "tlabSize", class: classLong},
+	}},
+	{id: classMonitorEnter, name: MonitorEnter, fields: []fieldDesc{
+		{name: "startTime", class: classLong},
+		{name: "duration", class: classLong},
+		{name: "stackTrace", class: classStackTrace, constantPool: true},
+	}},
+	{id: classThreadPark, name: ThreadPark, fields: []fieldDesc{
+		{name: "startTime", class: classLong},
+		{name: "duration", class: classLong},
+		{name: "stackTrace", class: classStackTrace, constantPool: true},
+	}},
+	{id: classSelfNested, name: SelfNested, fields: []fieldDesc{
+		{name: "nested", class: classSelfNested},
+	}},
+	{id: classActiveSetting, name: "jdk.ActiveSetting", fields: []fieldDesc{
+		{name: "startTime", class: classLong},
+		{name: "id", class: classLong},
+		{name: "name", class: classString},
+		{name: "value", class: classString},
+	}},
+}
+
+type buffer struct {
+	bytes.Buffer
+}
+
+func (b *buffer) varint(v int64) {
+	u := uint64(v)
+	for i := 0; i < 8; i++ {
+		if u < 0x80 {
+			b.WriteByte(byte(u))
+			return
+		}
+		b.WriteByte(byte(u) | 0x80)
+		u >>= 7
+	}
+	b.WriteByte(byte(u))
+}
+
+func (b *buffer) string(s string) {
+	if s == "" {
+		b.WriteByte(1)
+		return
+	}
+	b.WriteByte(3)
+	b.varint(int64(len(s)))
+	b.WriteString(s)
+}
+
+// event writes the event with its size padded to 4 bytes, like the JVM does.
+func (b *buffer) event(typ int64, body []byte) {
+	e := buffer{}
+	e.varint(typ)
+	e.Write(body)
+
+	size := 4 + e.Len()
+	b.WriteByte(byte(size) | 0x80)
+	b.WriteByte(byte(size>>7) | 0x80)
+	b.WriteByte(byte(size>>14) | 0x80)
+	b.WriteByte(byte(size>>21) & 0x7f)
+	b.Write(e.Bytes())
+}
+
+// pool assigns keys to values of a constant pool, starting at 1.
+type pool struct {
+	keys   map[string]int64
+	values []string
+}
+
+func (p *pool) key(v string) int64 {
+	if p.keys == nil {
+		p.keys = map[string]int64{}
+	}
+	if k, ok := p.keys[v]; ok {
+		return k
+	}
+	p.values = append(p.values, v)
+	p.keys[v] = int64(len(p.values))
+	return p.keys[v]
+}
+
+// NewRecording returns a recording of a single chunk with compressed
+// integers and ticks of nanoseconds, holding the events and, if cpuPeriod is
+// not empty, the period setting of the execution sampler, e.g. "10 ms".
+func NewRecording(start time.Time, duration time.Duration, cpuPeriod string, events []Event) []byte {
+	var symbols, classNames, methods, stacks pool
+	methodParts := map[string][2]int64{}
+	stackFrames := map[string][]jfr.Frame{}
+
+	stackKey := func(frames []jfr.Frame) int64 {
+		parts := make([]string, 0, len(frames))
+		for _, f := range frames {
+			parts = append(parts, f.Function+":"+strconv.FormatInt(f.Line, 10))
+
+			if _, ok := methodParts[f.Function]; ok {
+				methods.key(f.Function)
+				continue
+			}
+			className, methodName := "", f.Function
+			if i := strings.LastIndexByte(f.Function, '.'); i >= 0 {
+				className, methodName = strings.ReplaceAll(f.Function[:i], ".", "/"), f.Function[i+1:]
+			}
+			methodParts[f.Function] = [2]int64{classNames.key(className), symbols.key(methodName)}
+			methods.key(f.Function)
+		}
+		k := strings.Join(parts, ";")
+		stackFrames[k] = frames
+		return stacks.key(k)
+	}
+
+	body := buffer{}
+	body.Write(make([]byte, 68))
+
+	if cpuPeriod != "" {
+		e := buffer{}
+		e.varint(0)
+		e.varint(classExecutionSample)
+		e.string("period")
+		e.string(cpuPeriod)
+		body.event(classActiveSetting, e.Bytes())
+	}
+
+	selfNested := 0
+	for _, ev := range events {
+		e := buffer{}
+		switch ev.Type {
+		case ExecutionSample:
+			e.varint(0)
+			e.varint(stackKey(ev.Stack))
+			body.event(classExecutionSample, e.Bytes())
+		case AllocationSample:
+			e.varint(0)
+			e.varint(stackKey(ev.Stack))
+			e.varint(ev.Value)
+			body.event(classAllocationSample, e.Bytes())
+		case AllocationInNewTLAB:
+			e.varint(0)
+			e.varint(stackKey(ev.Stack))
+			e.varint(ev.Value)
+			body.event(classAllocationInNewTLAB, e.Bytes())
+		case MonitorEnter:
+			e.varint(0)
+			e.varint(ev.Value)
+			e.varint(stackKey(ev.Stack))
+			body.event(classMonitorEnter, e.Bytes())
+		case ThreadPark:
+			e.varint(0)
+			e.varint(ev.Value)
+			e.varint(stackKey(ev.Stack))
+			body.event(classThreadPark, e.Bytes())
+		case SelfNested:
+			selfNested++
+		}
+	}
+
+	// Class names are symbols themselves.
+	classSymbols := make([]int64, len(classNames.values))
+	for i, name := range classNames.values {
+		classSymbols[i] = symbols.key(name)
+	}
+
+	cp := buffer{}
+	cp.varint(0)
+	cp.varint(0)
+	cp.varint(0)
+	cp.WriteByte(0)
+	if selfNested > 0 {
+		cp.varint(5)
+		cp.varint(classSelfNested)
+		cp.varint(int64(selfNested))
+		for i := 0; i < selfNested; i++ {
+			cp.varint(int64(i + 1))
+		}
+	} else {
+		cp.varint(4)
+	}
+
+	cp.varint(classSymbol)
+	cp.varint(int64(len(symbols.values)))
+	for i, s := range symbols.values {
+		cp.varint(int64(i + 1))
+		cp.string(s)
+	}
+
+	cp.varint(classClass)
+	cp.varint(int64(len(classNames.values)))
+	for i := range classNames.values {
+		cp.varint(int64(i + 1))
+		cp.varint(classSymbols[i])
+	}
+
+	cp.varint(classMethod)
+	cp.varint(int64(len(methods.values)))
+	for i, m := range methods.values {
+		cp.varint(int64(i + 1))
+		cp.varint(methodParts[m][0])
+		cp.varint(methodParts[m][1])
+	}
+
+	cp.varint(classStackTrace)
+	cp.varint(int64(len(stacks.values)))
+	for i, k := range stacks.values {
+		cp.varint(int64(i + 1))
+		cp.WriteByte(0)
+		frames := stackFrames[k]
+		cp.varint(int64(len(frames)))
+		for _, f := range frames {
+			cp.varint(methods.key(f.Function))
+			cp.varint(f.Line)
+			cp.varint(0)
+		}
+	}
+
+	cpOffset := int64(body.Len())
+	body.event(1, cp.Bytes())
+
+	metadataOffset := int64(body.Len())
+	body.event(0, metadata())
+
+	b := body.Bytes()
+	copy(b, "FLR\x00")
+	binary.BigEndian.PutUint16(b[4:], 2)
+	binary.BigEndian.PutUint16(b[6:], 1)
+	binary.BigEndian.PutUint64(b[8:], uint64(len(b)))
+	binary.BigEndian.PutUint64(b[16:], uint64(cpOffset))
+	binary.BigEndian.PutUint64(b[24:], uint64(metadataOffset))
+	binary.BigEndian.PutUint64(b[32:], uint64(start.UnixNano()))
+	binary.BigEndian.PutUint64(b[40:], uint64(duration))
+	binary.BigEndian.PutUint64(b[48:], 0)
+	binary.BigEndian.PutUint64(b[56:], uint64(time.Second))
+	binary.BigEndian.PutUint32(b[64:], 1)
+
+	return b
+}
+
+type element struct {
+	name     string
+	attrs    map[string]string
+	children []element
+}
+
+func metadata() []byte {
+	root := element{name: "root"}
+	md := element{name: "metadata"}
+	for _, c := range classes {
+		ce := element{name: "class", attrs: map[string]string{
+			"id":   strconv.Itoa(c.id),
+			"name": c.name,
+		}}
+		for _, f := range c.fields {
+			fe := element{name: "field", attrs: map[string]string{
+				"name":  f.name,
+				"class": strconv.Itoa(f.class),
+			}}
+			if f.constantPool {
+				fe.attrs["constantPool"] = "true"
+			}
+			if f.array {
+				fe.attrs["dimension"] = "1"
+			}
+			ce.children = append(ce.children, fe)
+		}
+		md.children = append(md.children, ce)
+	}
+	root.children = append(root.children, md)
+
+	var strs pool
+	var collect func(e element)
+	collect = func(e element) {
+		strs.key(e.name)
+		for _, k := range sortedKeys(e.attrs) {
+			strs.key(k)
+			strs.key(e.attrs[k])
+		}
+		for _, c := range e.children {
+			collect(c)
+		}
+	}
+	collect(root)
+
+	b := buffer{}
+	b.varint(0)
+	b.varint(0)
+	b.varint(0)
+	b.varint(int64(len(strs.values)))
+	for _, s := range strs.values {
+		b.string(s)
+	}
+
+	var write func(e element)
+	write = func(e element) {
+		b.varint(strs.key(e.name) - 1)
+		keys := sortedKeys(e.attrs)
+		b.varint(int64(len(keys)))
+		for _, k := range keys {
+			b.varint(strs.key(k) - 1)
+			b.varint(strs.key(e.attrs[k]) - 1)
+		}
+		b.varint(int64(len(e.children)))
+		for _, c := range e.children {
+			write(c)
+		}
+	}
+	write(root)
+
+	return b.Bytes()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,473 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jfr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Event types with fixed IDs.
+const (
+	eventMetadata     = 0
+	eventConstantPool = 1
+)
+
+// maxElementDepth bounds the nesting of metadata elements and of objects.
+const maxElementDepth = 32
+
+// class is a type described by the metadata of a chunk. Primitive types and
+// strings have no fields.
+type class struct {
+	id     int64
+	name   string
+	fields []field
+	index  map[string]int
+}
+
+type field struct {
+	name         string
+	class        int64
+	constantPool bool
+	array        bool
+}
+
+// ref references the value with the key in the constant pool of the class.
+type ref struct {
+	class int64
+	key   int64
+}
+
+// object is a value of a class with fields. Its values are in the order of
+// the fields of the class, and not resolved.
+type object struct {
+	class  *class
+	values []interface{}
+}
+
+// raw returns the unresolved value of the field with the given name, or nil
+// if the class has no such field.
+func (o *object) raw(name string) interface{} {
+	i, ok := o.class.index[name]
+	if !ok {
+		return nil
+	}
+	return o.values[i]
+}
+
+// element is an element of the metadata tree.
+type element struct {
+	name     string
+	attrs    map[string]string
+	children []*element
+}
+
+// chunkParser parses a single chunk, whose metadata and constant pools are
+// only valid within the chunk.
+type chunkParser struct {
+	r      reader
+	header chunkHeader
+
+	classes     map[int64]*class
+	stringClass int64
+	pools       map[int64]map[int64]interface{}
+}
+
+func newChunkParser(b []byte, h chunkHeader) *chunkParser {
+	return &chunkParser{
+		r:       reader{b: b, compressed: h.features&featureCompressedInts != 0},
+		header:  h,
+		classes: map[int64]*class{},
+		pools:   map[int64]map[int64]interface{}{},
+	}
+}
+
+// seek moves the reader to the offset within the chunk, which must be past
+// the header.
+func (p *chunkParser) seek(offset int64) error {
+	if offset < chunkHeaderSize || offset >= int64(len(p.r.b)) {
+		return fmt.Errorf("offset %d out of range", offset)
+	}
+	p.r.pos = int(offset)
+	return nil
+}
+
+// readEventHeader reads the size and type of the event at the current
+// position.
+func (p *chunkParser) readEventHeader() (int64, int64, error) {
+	size, err := p.r.int()
+	if err != nil {
+		return 0, 0, err
+	}
+	if size <= 0 {
+		return 0, 0, fmt.Errorf("invalid event size %d", size)
+	}
+	typ, err := p.r.long()
+	if err != nil {
+		return 0, 0, err
+	}
+	return size, typ, nil
+}
+
+func (p *chunkParser) readMetadata() error {
+	if err := p.seek(p.header.metadataOffset); err != nil {
+		return fmt.Errorf("metadata: %w", err)
+	}
+
+	_, typ, err := p.readEventHeader()
+	if err != nil {
+		return fmt.Errorf("metadata: %w", err)
+	}
+	if typ != eventMetadata {
+		return fmt.Errorf("metadata: unexpected event type %d", typ)
+	}
+	// Start time, duration and ID of the metadata.
+	for i := 0; i < 3; i++ {
+		if _, err := p.r.long(); err != nil {
+			return fmt.Errorf("metadata: %w", err)
+		}
+	}
+
+	n, err := p.r.length()
+	if err != nil {
+		return fmt.Errorf("metadata strings: %w", err)
+	}
+	strs := make([]string, n)
+	for i := range strs {
+		v, err := p.r.string(0)
+		if err != nil {
+			return fmt.Errorf("metadata strings: %w", err)
+		}
+		if v != nil {
+			s, ok := v.(string)
+			if !ok {
+				return errors.New("metadata strings: unexpected constant pool reference")
+			}
+			strs[i] = s
+		}
+	}
+
+	root, err := p.readElement(strs, 0)
+	if err != nil {
+		return fmt.Errorf("metadata: %w", err)
+	}
+
+	for _, e := range root.children {
+		if e.name != "metadata" {
+			continue
+		}
+		for _, ce := range e.children {
+			if ce.name != "class" {
+				continue
+			}
+			c, err := parseClass(ce)
+			if err != nil {
+				return fmt.Errorf("metadata: %w", err)
+			}
+			p.classes[c.id] = c
+			if c.name == "java.lang.String" {
+				p.stringClass = c.id
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *chunkParser) readElement(strs []string, depth int) (*element, error) {
+	if depth > maxElementDepth {
+		return nil, errors.New("elements nested too deep")
+	}
+
+	str := func() (string, error) {
+		i, err := p.r.int()
+		if err != nil {
+			return "", err
+		}
+		if i < 0 || i >= int64(len(strs)) {
+			return "", fmt.Errorf("string index %d out of range", i)
+		}
+		return strs[i], nil
+	}
+
+	name, err := str()
+	if err != nil {
+		return nil, err
+	}
+	e := &element{name: name, attrs: map[string]string{}}
+
+	n, err := p.r.length()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		k, err := str()
+		if err != nil {
+			return nil, err
+		}
+		v, err := str()
+		if err != nil {
+			return nil, err
+		}
+		e.attrs[k] = v
+	}
+
+	n, err = p.r.length()
+	if err != nil {
+		return nil, err
+	}
+	e.children = make([]*element, 0, n)
+	for i := 0; i < n; i++ {
+		c, err := p.readElement(strs, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		e.children = append(e.children, c)
+	}
+
+	return e, nil
+}
+
+func parseClass(e *element) (*class, error) {
+	id, err := strconv.ParseInt(e.attrs["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("class %q: invalid id %q", e.attrs["name"], e.attrs["id"])
+	}
+
+	c := &class{
+		id:    id,
+		name:  e.attrs["name"],
+		index: map[string]int{},
+	}
+	for _, fe := range e.children {
+		if fe.name != "field" {
+			continue
+		}
+		fid, err := strconv.ParseInt(fe.attrs["class"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("class %q: field %q: invalid class %q", c.name, fe.attrs["name"], fe.attrs["class"])
+		}
+		c.index[fe.attrs["name"]] = len(c.fields)
+		c.fields = append(c.fields, field{
+			name:         fe.attrs["name"],
+			class:        fid,
+			constantPool: fe.attrs["constantPool"] == "true",
+			array:        fe.attrs["dimension"] == "1",
+		})
+	}
+
+	return c, nil
+}
+
+// readConstantPools reads the chain of constant pool events, starting at the
+// one the header points to, each pointing to the next one relative to itself.
+func (p *chunkParser) readConstantPools() error {
+	seen := map[int64]struct{}{}
+	offset := p.header.constantPoolOffset
+	for delta := offset; delta != 0; offset += delta {
+		if _, ok := seen[offset]; ok {
+			return fmt.Errorf("constant pool: cycle at offset %d", offset)
+		}
+		seen[offset] = struct{}{}
+		if err := p.seek(offset); err != nil {
+			return fmt.Errorf("constant pool: %w", err)
+		}
+
+		_, typ, err := p.readEventHeader()
+		if err != nil {
+			return fmt.Errorf("constant pool: %w", err)
+		}
+		if typ != eventConstantPool {
+			return fmt.Errorf("constant pool: unexpected event type %d", typ)
+		}
+		// Start time and duration.
+		for i := 0; i < 2; i++ {
+			if _, err := p.r.long(); err != nil {
+				return fmt.Errorf("constant pool: %w", err)
+			}
+		}
+		if delta, err = p.r.long(); err != nil {
+			return fmt.Errorf("constant pool: %w", err)
+		}
+		// Whether the pool was written on flush.
+		if _, err := p.r.byte(); err != nil {
+			return fmt.Errorf("constant pool: %w", err)
+		}
+
+		n, err := p.r.length()
+		if err != nil {
+			return fmt.Errorf("constant pool: %w", err)
+		}
+		for i := 0; i < n; i++ {
+			if err := p.readConstantPool(); err != nil {
+				return fmt.Errorf("constant pool: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *chunkParser) readConstantPool() error {
+	id, err := p.r.long()
+	if err != nil {
+		return err
+	}
+	c, ok := p.classes[id]
+	if !ok {
+		return fmt.Errorf("unknown class %d", id)
+	}
+
+	n, err := p.r.length()
+	if err != nil {
+		return err
+	}
+	pool, ok := p.pools[id]
+	if !ok {
+		pool = make(map[int64]interface{}, n)
+		p.pools[id] = pool
+	}
+	for i := 0; i < n; i++ {
+		key, err := p.r.long()
+		if err != nil {
+			return err
+		}
+		v, err := p.readValue(c, 0)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		pool[key] = v
+	}
+
+	return nil
+}
+
+// readValue reads a value of the class, which is either a primitive value, a
+// string or a reference to one, or an object. The depth is the nesting of the
+// value in objects read inline, which is bounded as the metadata may define
+// classes with inline fields of their own class.
+func (p *chunkParser) readValue(c *class, depth int) (interface{}, error) {
+	switch c.name {
+	case "boolean":
+		b, err := p.r.byte()
+		return b != 0, err
+	case "byte":
+		b, err := p.r.byte()
+		return int64(int8(b)), err
+	case "char", "short":
+		return p.r.short()
+	case "int":
+		return p.r.int()
+	case "long":
+		return p.r.long()
+	case "float":
+		return p.r.float()
+	case "double":
+		return p.r.double()
+	case "java.lang.String":
+		return p.r.string(p.stringClass)
+	}
+
+	if depth > maxElementDepth {
+		return nil, fmt.Errorf("%s: objects nested deeper than %d", c.name, maxElementDepth)
+	}
+
+	o := &object{class: c, values: make([]interface{}, len(c.fields))}
+	for i, f := range c.fields {
+		v, err := p.readField(f, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		o.values[i] = v
+	}
+
+	return o, nil
+}
+
+func (p *chunkParser) readField(f field, depth int) (interface{}, error) {
+	if !f.array {
+		return p.readFieldValue(f, depth)
+	}
+
+	n, err := p.r.length()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i := range values {
+		if values[i], err = p.readFieldValue(f, depth); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (p *chunkParser) readFieldValue(f field, depth int) (interface{}, error) {
+	if f.constantPool {
+		key, err := p.r.long()
+		if err != nil {
+			return nil, err
+		}
+		return ref{class: f.class, key: key}, nil
+	}
+
+	c, ok := p.classes[f.class]
+	if !ok {
+		return nil, fmt.Errorf("unknown class %d", f.class)
+	}
+	return p.readValue(c, depth)
+}
+
+// resolve resolves the value if it is a reference to a constant pool. Values
+// missing from their constant pool resolve to nil.
+func (p *chunkParser) resolve(v interface{}) interface{} {
+	for i := 0; i < maxElementDepth; i++ {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		v = p.pools[r.class][r.key]
+	}
+	return nil
+}
+
+// object returns the resolved value of the field of the object if it is an
+// object.
+func (p *chunkParser) object(o *object, name string) *object {
+	v, _ := p.resolve(o.raw(name)).(*object)
+	return v
+}
+
+// long returns the resolved value of the field of the object if it is an
+// integer, and 0 otherwise.
+func (p *chunkParser) long(o *object, name string) int64 {
+	v, _ := p.resolve(o.raw(name)).(int64)
+	return v
+}
+
+// string returns the resolved value of the field of the object if it is a
+// string. Symbols are resolved to their string.
+func (p *chunkParser) string(o *object, name string) string {
+	switch v := p.resolve(o.raw(name)).(type) {
+	case string:
+		return v
+	case *object:
+		s, _ := p.resolve(v.raw("string")).(string)
+		return s
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jfr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf16"
+)
+
+// String encodings of JFR.
+const (
+	stringNull byte = iota
+	stringEmpty
+	stringConstantPool
+	stringUTF8
+	stringCharArray
+	stringLatin1
+)
+
+// reader reads the values of a chunk. Integers are either LEB128 encoded,
+// using all 8 bits of the ninth byte, or fixed size big-endian if the chunk
+// does not use compressed integers.
+type reader struct {
+	b          []byte
+	pos        int
+	compressed bool
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.b) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := r.b[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || n > len(r.b)-r.pos {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) varint() (int64, error) {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		v |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			return int64(v), nil
+		}
+	}
+
+	b, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	return int64(v | uint64(b)<<56), nil
+}
+
+// fixed reads a big-endian integer of the given size in bytes.
+func (r *reader) fixed(size int) (int64, error) {
+	b, err := r.bytes(size)
+	if err != nil {
+		return 0, err
+	}
+
+	switch size {
+	case 2:
+		return int64(int16(binary.BigEndian.Uint16(b))), nil
+	case 4:
+		return int64(int32(binary.BigEndian.Uint32(b))), nil
+	default:
+		return int64(binary.BigEndian.Uint64(b)), nil
+	}
+}
+
+func (r *reader) integer(size int) (int64, error) {
+	if r.compressed {
+		return r.varint()
+	}
+	return r.fixed(size)
+}
+
+func (r *reader) short() (int64, error) { return r.integer(2) }
+func (r *reader) int() (int64, error)   { return r.integer(4) }
+func (r *reader) long() (int64, error)  { return r.integer(8) }
+
+// length reads an int used as the length of a following sequence.
+func (r *reader) length() (int, error) {
+	n, err := r.int()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > int64(len(r.b)-r.pos) {
+		return 0, fmt.Errorf("invalid length %d", n)
+	}
+	return int(n), nil
+}
+
+func (r *reader) float() (float64, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+}
+
+func (r *reader) double() (float64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+}
+
+// string reads a string, which is either returned as a string or, if it is
+// stored in the constant pool of strings, as a reference to it.
+func (r *reader) string(stringClass int64) (interface{}, error) {
+	encoding, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch encoding {
+	case stringNull:
+		return nil, nil
+	case stringEmpty:
+		return "", nil
+	case stringConstantPool:
+		key, err := r.long()
+		if err != nil {
+			return nil, err
+		}
+		return ref{class: stringClass, key: key}, nil
+	case stringUTF8:
+		n, err := r.length()
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.bytes(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case stringCharArray:
+		n, err := r.length()
+		if err != nil {
+			return nil, err
+		}
+		chars := make([]uint16, n)
+		for i := range chars {
+			c, err := r.short()
+			if err != nil {
+				return nil, err
+			}
+			chars[i] = uint16(c)
+		}
+		return string(utf16.Decode(chars)), nil
+	case stringLatin1:
+		n, err := r.length()
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.bytes(n)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return string(runes), nil
+	default:
+		return nil, fmt.Errorf("unknown string encoding %d", encoding)
+	}
+}
@@ -14,26 +14,44 @@
 package metastore
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 // BadgerMetastore is an implementation of the metastore using the badger KV
-// store.
+// store. All keys are namespaced by the tenant of the request context.
 type BadgerMetastore struct {
 	tracer trace.Tracer
 
 	db *badger.DB
 
+	// gcMtx is held for reading while entries are got or created, and for
+	// writing while garbage collection sweeps them, so that an entry reused
+	// during a sweep is either kept or created again.
+	gcMtx sync.RWMutex
+	// gcVersions are the versions of the database each tenant was last
+	// garbage collected at, and openVersion the version it was opened at.
+	// Garbage collection only deletes entries that were neither written nor
+	// reused since, as samples referencing them may not have been written
+	// yet.
+	gcVersions  map[string]uint64
+	openVersion uint64
+
 	pb.UnimplementedMetastoreServiceServer
 }
 
@@ -59,6 +77,10 @@ func (l *BadgerLogger) Debugf(f string, v ...interface{}) {
 
 var _ pb.MetastoreServiceServer = &BadgerMetastore{}
 
+// valueLogGCDiscardRatio is the ratio of discardable data a value log file
+// must contain to be rewritten.
+const valueLogGCDiscardRatio = 0.5
+
 // NewBadgerMetastore returns a new BadgerMetastore with using in-memory badger
 // instance.
 func NewBadgerMetastore(
@@ -72,9 +94,144 @@ func NewBadgerMetastore(
 	}
 
 	return &BadgerMetastore{
-		db:     db,
-		tracer: tracer,
+		db:          db,
+		tracer:      tracer,
+		gcVersions:  map[string]uint64{},
+		openVersion: db.MaxVersion(),
+	}
+}
+
+// NewBadgerDiskMetastore returns a new BadgerMetastore using a badger instance
+// that persists its data in the given directory.
+func NewBadgerDiskMetastore(
+	logger log.Logger,
+	reg prometheus.Registerer,
+	tracer trace.Tracer,
+	dir string,
+) (*BadgerMetastore, error) {
+	db, err := badger.Open(badger.DefaultOptions(dir).WithLogger(&BadgerLogger{logger: logger}))
+	if err != nil {
+		return nil, fmt.Errorf("open badger in %q: %w", dir, err)
+	}
+
+	return &BadgerMetastore{
+		db:          db,
+		tracer:      tracer,
+		gcVersions:  map[string]uint64{},
+		openVersion: db.MaxVersion(),
+	}, nil
+}
+
+// RunValueLogGC periodically garbage collects the value log of the badger
+// instance until the context is canceled. It must only be used with an
+// on-disk metastore.
+func (m *BadgerMetastore) RunValueLogGC(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// Each run rewrites at most one value log file, so keep going
+			// until there is nothing left to rewrite.
+			for {
+				err := m.db.RunValueLogGC(valueLogGCDiscardRatio)
+				if errors.Is(err, badger.ErrNoRewrite) || errors.Is(err, badger.ErrRejected) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// Tenants returns all tenants that have metadata stored, always including the
+// default tenant.
+func (m *BadgerMetastore) Tenants(ctx context.Context) ([]string, error) {
+	tenants := []string{""}
+	err := m.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = []byte(tenantsKeyPrefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		it.Rewind()
+		for it.Valid() {
+			key := it.Item().Key()[len(tenantsKeyPrefix):]
+			i := bytes.IndexByte(key, '/')
+			if i == -1 {
+				it.Next()
+				continue
+			}
+
+			t := string(key[:i])
+			tenants = append(tenants, t)
+
+			// Skip the remaining keys of the tenant, '0' directly follows '/'.
+			it.Seek([]byte(tenantsKeyPrefix + t + "0"))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+// Close closes the underlying badger instance.
+func (m *BadgerMetastore) Close() error {
+	return m.db.Close()
+}
+
+// maxUpdateAttempts is the number of times an update transaction is attempted
+// before a conflict with concurrent transactions is returned.
+const maxUpdateAttempts = 10
+
+// update runs the function in a read-write transaction. The transaction is
+// retried if it conflicts with a concurrent transaction, so the function must
+// reset any state it accumulates.
+func (m *BadgerMetastore) update(fn func(txn *badger.Txn) error) error {
+	var err error
+	for i := 0; i < maxUpdateAttempts; i++ {
+		err = m.db.Update(fn)
+		if !errors.Is(err, badger.ErrConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+// touch writes an existing entry again if the next garbage collection of the
+// tenant could delete it, so that it is not deleted before the samples reusing
+// it are written. Entries are touched at most once between two collections.
+// It must be called with gcMtx held.
+func (m *BadgerMetastore) touch(ctx context.Context, txn *badger.Txn, item *badger.Item) error {
+	if item.Version() > m.gcVersion(ctx) {
+		return nil
+	}
+
+	val, err := item.ValueCopy(nil)
+	if err != nil {
+		return err
+	}
+
+	return txn.Set(item.KeyCopy(nil), val)
+}
+
+// gcVersion returns the version of the database the tenant of the context was
+// last garbage collected at. It must be called with gcMtx held.
+func (m *BadgerMetastore) gcVersion(ctx context.Context) uint64 {
+	if version, ok := m.gcVersions[tenant.FromContext(ctx)]; ok {
+		return version
 	}
+	return m.openVersion
 }
 
 func (m *BadgerMetastore) Mappings(ctx context.Context, r *pb.MappingsRequest) (*pb.MappingsResponse, error) {
@@ -84,7 +241,7 @@ func (m *BadgerMetastore) Mappings(ctx context.Context, r *pb.MappingsRequest) (
 
 	mappingKeys := make([][]byte, 0, len(r.MappingIds))
 	for _, id := range r.MappingIds {
-		mappingKeys = append(mappingKeys, []byte(MakeMappingKeyWithID(id)))
+		mappingKeys = append(mappingKeys, tenantKey(ctx, MakeMappingKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -125,9 +282,13 @@ func (m *BadgerMetastore) GetOrCreateMappings(ctx context.Context, r *pb.GetOrCr
 		mappingKeys = append(mappingKeys, MakeMappingKey(id))
 	}
 
-	err := m.db.Update(func(txn *badger.Txn) error {
+	m.gcMtx.RLock()
+	defer m.gcMtx.RUnlock()
+
+	err := m.update(func(txn *badger.Txn) error {
+		res.Mappings = res.Mappings[:0]
 		for i, mappingKey := range mappingKeys {
-			item, err := txn.Get([]byte(mappingKey))
+			item, err := txn.Get(tenantKey(ctx, mappingKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -139,13 +300,17 @@ func (m *BadgerMetastore) GetOrCreateMappings(ctx context.Context, r *pb.GetOrCr
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(mappingKey), b); err != nil {
+				if err := txn.Set(tenantKey(ctx, mappingKey), b); err != nil {
 					return err
 				}
 				res.Mappings = append(res.Mappings, mapping)
 				continue
 			}
 
+			if err := m.touch(ctx, txn, item); err != nil {
+				return err
+			}
+
 			err = item.Value(func(val []byte) error {
 				mapping := &pb.Mapping{}
 				err := mapping.UnmarshalVT(val)
@@ -174,7 +339,7 @@ func (m *BadgerMetastore) Functions(ctx context.Context, r *pb.FunctionsRequest)
 
 	functionKeys := make([][]byte, 0, len(r.FunctionIds))
 	for _, id := range r.FunctionIds {
-		functionKeys = append(functionKeys, []byte(MakeFunctionKeyWithID(id)))
+		functionKeys = append(functionKeys, tenantKey(ctx, MakeFunctionKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -215,9 +380,13 @@ func (m *BadgerMetastore) GetOrCreateFunctions(ctx context.Context, r *pb.GetOrC
 		functionKeys = append(functionKeys, MakeFunctionKey(function))
 	}
 
-	err := m.db.Update(func(txn *badger.Txn) error {
+	m.gcMtx.RLock()
+	defer m.gcMtx.RUnlock()
+
+	err := m.update(func(txn *badger.Txn) error {
+		res.Functions = res.Functions[:0]
 		for i, functionKey := range functionKeys {
-			item, err := txn.Get([]byte(functionKey))
+			item, err := txn.Get(tenantKey(ctx, functionKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -229,13 +398,17 @@ func (m *BadgerMetastore) GetOrCreateFunctions(ctx context.Context, r *pb.GetOrC
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(functionKey), b); err != nil {
+				if err := txn.Set(tenantKey(ctx, functionKey), b); err != nil {
 					return err
 				}
 				res.Functions = append(res.Functions, function)
 				continue
 			}
 
+			if err := m.touch(ctx, txn, item); err != nil {
+				return err
+			}
+
 			err = item.Value(func(val []byte) error {
 				function := &pb.Function{}
 				err := function.UnmarshalVT(val)
@@ -264,7 +437,7 @@ func (m *BadgerMetastore) LocationLines(ctx context.Context, r *pb.LocationLines
 
 	locationLineKeys := make([][]byte, 0, len(r.LocationIds))
 	for _, id := range r.LocationIds {
-		locationLineKeys = append(locationLineKeys, []byte(MakeLocationLinesKeyWithID(id)))
+		locationLineKeys = append(locationLineKeys, tenantKey(ctx, MakeLocationLinesKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -307,7 +480,7 @@ func (m *BadgerMetastore) Locations(ctx context.Context, r *pb.LocationsRequest)
 
 	locationKeys := make([][]byte, 0, len(r.LocationIds))
 	for _, id := range r.LocationIds {
-		locationKeys = append(locationKeys, []byte(MakeLocationKeyWithID(id)))
+		locationKeys = append(locationKeys, tenantKey(ctx, MakeLocationKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
@@ -357,9 +530,15 @@ func (m *BadgerMetastore) GetOrCreateLocations(ctx context.Context, r *pb.GetOrC
 	symbolizedLocationKeys := make([]string, 0, len(r.Locations))
 	symbolizedLocations := make([]*pb.Location, 0, len(r.Locations))
 
-	err := m.db.Update(func(txn *badger.Txn) error {
+	m.gcMtx.RLock()
+	defer m.gcMtx.RUnlock()
+
+	err := m.update(func(txn *badger.Txn) error {
+		res.Locations = res.Locations[:0]
+		symbolizedLocationKeys = symbolizedLocationKeys[:0]
+		symbolizedLocations = symbolizedLocations[:0]
 		for i, locationKey := range locationKeys {
-			item, err := txn.Get([]byte(locationKey))
+			item, err := txn.Get(tenantKey(ctx, locationKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -371,14 +550,14 @@ func (m *BadgerMetastore) GetOrCreateLocations(ctx context.Context, r *pb.GetOrC
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(locationKey), b); err != nil {
+				if err := txn.Set(tenantKey(ctx, locationKey), b); err != nil {
 					return err
 				}
 				res.Locations = append(res.Locations, location)
 
 				if location.MappingId != "" && location.Address != 0 && (location.Lines == nil || len(location.Lines.Entries) == 0) {
 					unsymbolizableKey := MakeUnsymbolizedLocationKeyWithID(location.Id)
-					if err := txn.Set([]byte(unsymbolizableKey), []byte{}); err != nil {
+					if err := txn.Set(tenantKey(ctx, unsymbolizableKey), []byte{}); err != nil {
 						return err
 					}
 					continue
@@ -390,6 +569,10 @@ func (m *BadgerMetastore) GetOrCreateLocations(ctx context.Context, r *pb.GetOrC
 				continue
 			}
 
+			if err := m.touch(ctx, txn, item); err != nil {
+				return err
+			}
+
 			err = item.Value(func(val []byte) error {
 				location := &pb.Location{}
 				err := location.UnmarshalVT(val)
@@ -421,10 +604,11 @@ func (m *BadgerMetastore) UnsymbolizedLocations(ctx context.Context, r *pb.Unsym
 		defer it.Close()
 
 		locationKeys := [][]byte{}
-		prefix := []byte(UnsymbolizedLocationLinesKeyPrefix)
+		tenantPrefix := tenantKey(ctx, "")
+		prefix := tenantKey(ctx, UnsymbolizedLocationLinesKeyPrefix)
 		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
-			key := MakeLocationKeyWithID(LocationIDFromUnsymbolizedKey(string(it.Item().Key())))
-			locationKeys = append(locationKeys, []byte(key))
+			key := MakeLocationKeyWithID(LocationIDFromUnsymbolizedKey(string(it.Item().Key()[len(tenantPrefix):])))
+			locationKeys = append(locationKeys, tenantKey(ctx, key))
 		}
 
 		locations = make([]*pb.Location, 0, len(locationKeys))
@@ -448,7 +632,7 @@ func (m *BadgerMetastore) CreateLocationLines(ctx context.Context, r *pb.CreateL
 		locationIDs = append(locationIDs, MakeLocationID(location))
 	}
 
-	err := m.db.Update(func(txn *badger.Txn) error {
+	err := m.update(func(txn *badger.Txn) error {
 		return m.createLocationLines(ctx, txn, locationIDs, r.Locations)
 	})
 	if err != nil {
@@ -464,11 +648,11 @@ func (m *BadgerMetastore) createLocationLines(ctx context.Context, txn *badger.T
 		if err != nil {
 			return err
 		}
-		if err := txn.Set([]byte(MakeLocationLinesKeyWithID(locationID)), b); err != nil {
+		if err := txn.Set(tenantKey(ctx, MakeLocationLinesKeyWithID(locationID)), b); err != nil {
 			return err
 		}
 
-		if err := txn.Delete([]byte(MakeUnsymbolizedLocationKeyWithID(locationID))); err != nil {
+		if err := txn.Delete(tenantKey(ctx, MakeUnsymbolizedLocationKeyWithID(locationID))); err != nil {
 			return err
 		}
 	}
@@ -485,9 +669,13 @@ func (m *BadgerMetastore) GetOrCreateStacktraces(ctx context.Context, r *pb.GetO
 		stacktraceKeys = append(stacktraceKeys, MakeStacktraceKey(stacktrace))
 	}
 
-	err := m.db.Update(func(txn *badger.Txn) error {
+	m.gcMtx.RLock()
+	defer m.gcMtx.RUnlock()
+
+	err := m.update(func(txn *badger.Txn) error {
+		res.Stacktraces = res.Stacktraces[:0]
 		for i, stacktraceKey := range stacktraceKeys {
-			item, err := txn.Get([]byte(stacktraceKey))
+			item, err := txn.Get(tenantKey(ctx, stacktraceKey))
 			if err != nil && err != badger.ErrKeyNotFound {
 				return err
 			}
@@ -499,13 +687,17 @@ func (m *BadgerMetastore) GetOrCreateStacktraces(ctx context.Context, r *pb.GetO
 				if err != nil {
 					return err
 				}
-				if err := txn.Set([]byte(stacktraceKey), b); err != nil {
+				if err := txn.Set(tenantKey(ctx, stacktraceKey), b); err != nil {
 					return err
 				}
 				res.Stacktraces = append(res.Stacktraces, stacktrace)
 				continue
 			}
 
+			if err := m.touch(ctx, txn, item); err != nil {
+				return err
+			}
+
 			err = item.Value(func(val []byte) error {
 				stacktrace := &pb.Stacktrace{}
 				err := stacktrace.UnmarshalVT(val)
@@ -534,12 +726,15 @@ func (m *BadgerMetastore) Stacktraces(ctx context.Context, r *pb.StacktracesRequ
 
 	stacktraceKeys := make([][]byte, 0, len(r.StacktraceIds))
 	for _, id := range r.StacktraceIds {
-		stacktraceKeys = append(stacktraceKeys, []byte(MakeStacktraceKeyWithID(id)))
+		stacktraceKeys = append(stacktraceKeys, tenantKey(ctx, MakeStacktraceKeyWithID(id)))
 	}
 
 	err := m.db.View(func(txn *badger.Txn) error {
-		for _, stacktraceKey := range stacktraceKeys {
+		for i, stacktraceKey := range stacktraceKeys {
 			item, err := txn.Get(stacktraceKey)
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				return status.Errorf(codes.NotFound, "stacktrace %q not found", r.StacktraceIds[i])
+			}
 			if err != nil {
 				return err
 			}
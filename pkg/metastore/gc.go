@@ -0,0 +1,197 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v3"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// GarbageCollect deletes all stacktraces of the tenant of the context that are
+// not referenced anymore, followed by the locations, functions and mappings
+// that are not referenced by any of the remaining stacktraces. The
+// liveStacktraces function returns the IDs of all stacktraces of the tenant
+// that are still referenced. Entries written or reused since the previous
+// collection of the tenant are never deleted, as samples referencing them may
+// not have been written yet. It returns the number of deleted keys.
+func (m *BadgerMetastore) GarbageCollect(
+	ctx context.Context,
+	liveStacktraces func(context.Context) (map[string]struct{}, error),
+) (int, error) {
+	ctx, span := m.tracer.Start(ctx, "GarbageCollect")
+	defer span.End()
+
+	live, err := liveStacktraces(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get live stacktraces: %w", err)
+	}
+
+	// Entries are neither created nor reused while sweeping.
+	m.gcMtx.Lock()
+	defer m.gcMtx.Unlock()
+
+	version := m.gcVersion(ctx)
+
+	deleteKeys := [][]byte{}
+	err = m.db.View(func(txn *badger.Txn) error {
+		liveLocations := map[string]struct{}{}
+		keys, err := sweep(txn, tenantKey(ctx, stacktraceKeyPrefix), version, live, func(_ string, val []byte) error {
+			stacktrace := &pb.Stacktrace{}
+			if err := stacktrace.UnmarshalVT(val); err != nil {
+				return err
+			}
+			for _, id := range stacktrace.LocationIds {
+				liveLocations[id] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("sweep stacktraces: %w", err)
+		}
+		deleteKeys = append(deleteKeys, keys...)
+
+		keptLocations := map[string]struct{}{}
+		liveFunctions := map[string]struct{}{}
+		liveMappings := map[string]struct{}{}
+		locationsPrefix := tenantKey(ctx, locationsKeyPrefix)
+		keys, err = sweep(txn, locationsPrefix, version, liveLocations, func(id string, val []byte) error {
+			keptLocations[id] = struct{}{}
+			location := &pb.Location{}
+			if err := location.UnmarshalVT(val); err != nil {
+				return err
+			}
+			liveMappings[location.MappingId] = struct{}{}
+			if location.Lines != nil {
+				for _, line := range location.Lines.Entries {
+					liveFunctions[line.FunctionId] = struct{}{}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("sweep locations: %w", err)
+		}
+		deleteKeys = append(deleteKeys, keys...)
+		for _, key := range keys {
+			id := string(key[len(locationsPrefix):])
+			deleteKeys = append(deleteKeys,
+				tenantKey(ctx, MakeLocationLinesKeyWithID(id)),
+				tenantKey(ctx, MakeUnsymbolizedLocationKeyWithID(id)),
+			)
+		}
+
+		// Symbolized locations reference their functions through their
+		// location lines.
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = tenantKey(ctx, locationLinesKeyPrefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			id := string(it.Item().Key()[len(opts.Prefix):])
+			if _, ok := keptLocations[id]; !ok {
+				continue
+			}
+
+			err := it.Item().Value(func(val []byte) error {
+				locationLines := &pb.LocationLines{}
+				if err := locationLines.UnmarshalVT(val); err != nil {
+					return err
+				}
+				for _, line := range locationLines.Entries {
+					liveFunctions[line.FunctionId] = struct{}{}
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("read location lines: %w", err)
+			}
+		}
+
+		keys, err = sweep(txn, tenantKey(ctx, functionKeyPrefix), version, liveFunctions, nil)
+		if err != nil {
+			return fmt.Errorf("sweep functions: %w", err)
+		}
+		deleteKeys = append(deleteKeys, keys...)
+
+		keys, err = sweep(txn, tenantKey(ctx, mappingKeyPrefix), version, liveMappings, nil)
+		if err != nil {
+			return fmt.Errorf("sweep mappings: %w", err)
+		}
+		deleteKeys = append(deleteKeys, keys...)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	wb := m.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range deleteKeys {
+		if err := wb.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	if err := wb.Flush(); err != nil {
+		return 0, err
+	}
+
+	m.gcVersions[tenant.FromContext(ctx)] = m.db.MaxVersion()
+
+	return len(deleteKeys), nil
+}
+
+// sweep returns the keys with the given prefix whose ID is not in the live set
+// and that were written at or before the given version. The mark function is
+// called with the ID and value of every key that is kept.
+func sweep(
+	txn *badger.Txn,
+	prefix []byte,
+	version uint64,
+	live map[string]struct{},
+	mark func(id string, val []byte) error,
+) ([][]byte, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	opts.PrefetchValues = mark != nil
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	keys := [][]byte{}
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		id := string(item.Key()[len(prefix):])
+		_, ok := live[id]
+		if !ok && item.Version() <= version {
+			keys = append(keys, item.KeyCopy(nil))
+			continue
+		}
+
+		if mark != nil {
+			err := item.Value(func(val []byte) error {
+				return mark(id, val)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metastore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+)
+
+func TestGarbageCollect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewBadgerMetastore(
+		log.NewNopLogger(),
+		prometheus.NewRegistry(),
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+	defer m.Close()
+
+	mres, err := m.GetOrCreateMappings(ctx, &pb.GetOrCreateMappingsRequest{
+		Mappings: []*pb.Mapping{{Start: 1, Limit: 10, BuildId: "a"}, {Start: 1, Limit: 10, BuildId: "b"}},
+	})
+	require.NoError(t, err)
+	mappingA, mappingB := mres.Mappings[0].Id, mres.Mappings[1].Id
+
+	fres, err := m.GetOrCreateFunctions(ctx, &pb.GetOrCreateFunctionsRequest{
+		Functions: []*pb.Function{{Name: "a"}, {Name: "b"}},
+	})
+	require.NoError(t, err)
+	functionA, functionB := fres.Functions[0].Id, fres.Functions[1].Id
+
+	lres, err := m.GetOrCreateLocations(ctx, &pb.GetOrCreateLocationsRequest{
+		Locations: []*pb.Location{
+			{MappingId: mappingA, Address: 1},
+			{MappingId: mappingB, Address: 0, Lines: &pb.LocationLines{Entries: []*pb.Line{{FunctionId: functionB}}}},
+		},
+	})
+	require.NoError(t, err)
+	locationA, locationB := lres.Locations[0], lres.Locations[1]
+
+	// Symbolize the first location, so that it references the first function
+	// through its location lines.
+	locationA.Lines = &pb.LocationLines{Entries: []*pb.Line{{FunctionId: functionA}}}
+	_, err = m.CreateLocationLines(ctx, &pb.CreateLocationLinesRequest{Locations: []*pb.Location{locationA}})
+	require.NoError(t, err)
+
+	sres, err := m.GetOrCreateStacktraces(ctx, &pb.GetOrCreateStacktracesRequest{
+		Stacktraces: []*pb.Stacktrace{{LocationIds: []string{locationA.Id}}, {LocationIds: []string{locationB.Id}}},
+	})
+	require.NoError(t, err)
+	stacktraceA, stacktraceB := sres.Stacktraces[0].Id, sres.Stacktraces[1].Id
+
+	liveStacktraces := func(ctx context.Context) (map[string]struct{}, error) {
+		return map[string]struct{}{stacktraceA: {}}, nil
+	}
+
+	// Entries written since the previous collection are kept.
+	n, err := m.GarbageCollect(ctx, liveStacktraces)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	n, err = m.GarbageCollect(ctx, liveStacktraces)
+	require.NoError(t, err)
+	require.Greater(t, n, 0)
+
+	_, err = m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: []string{stacktraceA}})
+	require.NoError(t, err)
+	_, err = m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: []string{stacktraceB}})
+	require.Error(t, err)
+
+	_, err = m.Locations(ctx, &pb.LocationsRequest{LocationIds: []string{locationA.Id}})
+	require.NoError(t, err)
+	_, err = m.Locations(ctx, &pb.LocationsRequest{LocationIds: []string{locationB.Id}})
+	require.Error(t, err)
+
+	_, err = m.Functions(ctx, &pb.FunctionsRequest{FunctionIds: []string{functionA}})
+	require.NoError(t, err)
+	_, err = m.Functions(ctx, &pb.FunctionsRequest{FunctionIds: []string{functionB}})
+	require.Error(t, err)
+
+	_, err = m.Mappings(ctx, &pb.MappingsRequest{MappingIds: []string{mappingA}})
+	require.NoError(t, err)
+	_, err = m.Mappings(ctx, &pb.MappingsRequest{MappingIds: []string{mappingB}})
+	require.Error(t, err)
+}
+
+func TestGarbageCollect_Reused(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewBadgerMetastore(
+		log.NewNopLogger(),
+		prometheus.NewRegistry(),
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+	defer m.Close()
+
+	getOrCreate := func() string {
+		lres, err := m.GetOrCreateLocations(ctx, &pb.GetOrCreateLocationsRequest{
+			Locations: []*pb.Location{{MappingId: "m", Address: 1}},
+		})
+		require.NoError(t, err)
+		sres, err := m.GetOrCreateStacktraces(ctx, &pb.GetOrCreateStacktracesRequest{
+			Stacktraces: []*pb.Stacktrace{{LocationIds: []string{lres.Locations[0].Id}}},
+		})
+		require.NoError(t, err)
+		return sres.Stacktraces[0].Id
+	}
+	noneLive := func(ctx context.Context) (map[string]struct{}, error) {
+		return map[string]struct{}{}, nil
+	}
+
+	id := getOrCreate()
+	_, err := m.GarbageCollect(ctx, noneLive)
+	require.NoError(t, err)
+
+	// The stacktrace is reused, but the sample referencing it is not written
+	// yet when the next collection runs.
+	require.Equal(t, id, getOrCreate())
+	_, err = m.GarbageCollect(ctx, noneLive)
+	require.NoError(t, err)
+
+	sres, err := m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: []string{id}})
+	require.NoError(t, err)
+	_, err = m.Locations(ctx, &pb.LocationsRequest{LocationIds: sres.Stacktraces[0].LocationIds})
+	require.NoError(t, err)
+
+	// Once unreferenced and not reused for a whole collection, it is deleted.
+	_, err = m.GarbageCollect(ctx, noneLive)
+	require.NoError(t, err)
+	_, err = m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: []string{id}})
+	require.Error(t, err)
+}
+
+func TestGarbageCollect_ConcurrentIngestion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := NewBadgerMetastore(
+		log.NewNopLogger(),
+		prometheus.NewRegistry(),
+		trace.NewNoopTracerProvider().Tracer(""),
+	)
+	defer m.Close()
+
+	// Samples are written after their stacktraces were got or created, like
+	// the ingester does.
+	var mtx sync.Mutex
+	samples := map[string]struct{}{}
+	liveStacktraces := func(ctx context.Context) (map[string]struct{}, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		live := make(map[string]struct{}, len(samples))
+		for id := range samples {
+			live[id] = struct{}{}
+		}
+		return live, nil
+	}
+
+	ingested := make(chan struct{})
+	done := make(chan struct{})
+	var gcErr error
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ingested:
+				return
+			default:
+			}
+			if _, err := m.GarbageCollect(ctx, liveStacktraces); err != nil {
+				gcErr = err
+				return
+			}
+			// Samples are written well within the interval between two
+			// collections.
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	getOrCreate := func(address uint64) string {
+		lres, err := m.GetOrCreateLocations(ctx, &pb.GetOrCreateLocationsRequest{
+			Locations: []*pb.Location{{MappingId: "m", Address: address}},
+		})
+		require.NoError(t, err)
+		sres, err := m.GetOrCreateStacktraces(ctx, &pb.GetOrCreateStacktracesRequest{
+			Stacktraces: []*pb.Stacktrace{{LocationIds: []string{lres.Locations[0].Id}}},
+		})
+		require.NoError(t, err)
+		return sres.Stacktraces[0].Id
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				// The stacktrace is created, and dropped before a sample is
+				// written for it, so that collections consider it old once
+				// it is reused.
+				address := uint64(w*1000 + i + 1)
+				getOrCreate(address)
+				time.Sleep(25 * time.Millisecond)
+
+				id := getOrCreate(address)
+				// Give a collection a chance to run before the sample is
+				// written.
+				time.Sleep(time.Millisecond)
+
+				mtx.Lock()
+				samples[id] = struct{}{}
+				mtx.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(ingested)
+	<-done
+	require.NoError(t, gcErr)
+
+	// Every stacktrace a sample was written for, and its locations, still
+	// exist.
+	ids := []string{}
+	for id := range samples {
+		ids = append(ids, id)
+	}
+	sres, err := m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: ids})
+	require.NoError(t, err)
+	for _, s := range sres.Stacktraces {
+		_, err := m.Locations(ctx, &pb.LocationsRequest{LocationIds: s.LocationIds})
+		require.NoError(t, err)
+	}
+}
@@ -14,13 +14,32 @@
 package metastore
 
 import (
+	"context"
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/binary"
 
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
+// Keys of all tenants but the default tenant are namespaced by the tenant
+// `tenants/<tenant>/v1/...`.
+const tenantsKeyPrefix = "tenants/"
+
+// MakeTenantKeyPrefix returns the prefix of all keys of the tenant.
+func MakeTenantKeyPrefix(t string) string {
+	if t == "" {
+		return ""
+	}
+	return tenantsKeyPrefix + t + "/"
+}
+
+// tenantKey returns the key namespaced by the tenant of the context.
+func tenantKey(ctx context.Context, key string) []byte {
+	return []byte(MakeTenantKeyPrefix(tenant.FromContext(ctx)) + key)
+}
+
 // MakeLocationKey returns the key to be used to store/lookup the location in a
 // key-value store.
 func MakeLocationKey(l *pb.Location) string {
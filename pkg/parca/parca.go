@@ -22,6 +22,7 @@ import (
 	"os"
 	goruntime "runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -34,9 +35,12 @@ import (
 	"github.com/polarsignals/frostdb"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/objstore"
 	"github.com/thanos-io/objstore/client"
+	"github.com/thanos-io/objstore/filesystem"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
@@ -50,7 +54,6 @@ import (
 	"gopkg.in/yaml.v2"
 
 	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
-	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	querypb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	scrapepb "github.com/parca-dev/parca/gen/proto/go/parca/scrape/v1alpha1"
@@ -71,6 +74,11 @@ const (
 	symbolizationInterval   = 10 * time.Second
 	flagModeScraperOnly     = "scraper-only"
 	metaStoreBadgerInMemory = "badgerinmemory"
+	metaStoreBadger         = "badger"
+
+	metastoreValueLogGCInterval = 5 * time.Minute
+	retentionInterval           = 10 * time.Minute
+	downsamplingInterval        = time.Hour
 )
 
 type Flags struct {
@@ -86,14 +94,22 @@ type Flags struct {
 	MutexProfileFraction int `default:"0" help:"Fraction of mutex profile samples to collect."`
 	BlockProfileRate     int `default:"0" help:"Sample rate for block profile."`
 
-	StorageDebugValueLog bool  `default:"false" help:"Log every value written to the database into a separate file. This is only for debugging purposes to produce data to replay situations in tests."`
-	StorageGranuleSize   int   `default:"8196" help:"Granule size for storage."`
-	StorageActiveMemory  int64 `default:"536870912" help:"Amount of memory to use for active storage. Defaults to 512MB."`
+	StorageDebugValueLog   bool           `default:"false" help:"Log every value written to the database into a separate file. This is only for debugging purposes to produce data to replay situations in tests."`
+	StorageGranuleSize     int            `default:"8196" help:"Granule size for storage."`
+	StorageActiveMemory    int64          `default:"536870912" help:"Amount of memory to use for active storage. Defaults to 512MB."`
+	StoragePath            string         `default:"" help:"Path to persist stored profiles to. If empty, profiles are only kept in memory."`
+	StorageBucket          bool           `default:"false" help:"Persist stored profiles to the debuginfo object storage bucket under the storage path instead of the local filesystem."`
+	StorageWALPath         string         `default:"" help:"Path to keep a write-ahead log of ingested profiles in, to recover them after a crash. Only profiles written with WriteRaw and WriteStream, like scraped profiles, are logged, Arrow, folded, JFR and OTLP writes are not. Requires a storage path."`
+	StorageRetention       model.Duration `default:"0s" help:"How long to retain profiles for, e.g. 14d. Persisted blocks older than this are deleted and unreferenced metadata is garbage collected. Disabled if 0."`
+	StorageDownsampling    []string       `help:"Downsample persisted profiles to a coarser resolution once they are older than an age, given as <age>:<resolution> pairs, e.g. 2d:1m,7d:5m,30d:1h. Requires a storage path."`
+	StorageIngestWorkers   int            `default:"8" help:"Number of series of write streams to ingest concurrently."`
+	StorageIngestQueueSize int            `default:"256" help:"Number of series of write streams to queue for ingestion. Write streams are rejected with RESOURCE_EXHAUSTED once the queue is full."`
 
 	SymbolizerDemangleMode  string `default:"simple" help:"Mode to demangle C++ symbols. Default mode is simplified: no parameters, no templates, no return type" enum:"simple,full,none,templates"`
 	SymbolizerNumberOfTries int    `default:"3" help:"Number of tries to attempt to symbolize an unsybolized location"`
 
-	Metastore string `default:"badgerinmemory" help:"Which metastore implementation to use" enum:"badgerinmemory"`
+	Metastore          string `default:"badgerinmemory" help:"Which metastore implementation to use" enum:"badgerinmemory,badger"`
+	MetastoreDirectory string `default:"data/metastore" help:"Directory to store the metastore in when using the on-disk badger metastore."`
 
 	ProfileShareServer string `default:"api.pprof.me:443" help:"gRPC address to send share profile requests to."`
 
@@ -140,7 +156,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return runScraper(ctx, logger, reg, tracerProvider, flags, version, cfg)
 	}
 
-	var mStr metastorepb.MetastoreServiceServer
+	var mStr *metastore.BadgerMetastore
 	switch flags.Metastore {
 	case metaStoreBadgerInMemory:
 		mStr = metastore.NewBadgerMetastore(
@@ -148,19 +164,86 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			reg,
 			tracerProvider.Tracer(metaStoreBadgerInMemory),
 		)
+	case metaStoreBadger:
+		mStr, err = metastore.NewBadgerDiskMetastore(
+			logger,
+			reg,
+			tracerProvider.Tracer(metaStoreBadger),
+			flags.MetastoreDirectory,
+		)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to initialize metastore", "err", err)
+			return err
+		}
 	default:
 		err := fmt.Errorf("unknown metastore implementation: %s", flags.Metastore)
 		level.Error(logger).Log("msg", "failed to initialize metastore", "err", err)
 		return err
 	}
 
+	downsamplingTiers, err := parcacol.ParseDownsamplingTiers(flags.StorageDownsampling)
+	if err != nil {
+		level.Error(logger).Log("msg", "invalid storage configuration", "err", err)
+		return err
+	}
+
+	if len(downsamplingTiers) > 0 && flags.StoragePath == "" {
+		err := fmt.Errorf("downsampling requires a storage path to persist data to")
+		level.Error(logger).Log("msg", "invalid storage configuration", "err", err)
+		return err
+	}
+
+	if flags.StorageWALPath != "" && flags.StoragePath == "" {
+		err := fmt.Errorf("the write-ahead log requires a storage path to persist data to")
+		level.Error(logger).Log("msg", "invalid storage configuration", "err", err)
+		return err
+	}
+
+	if flags.StorageIngestWorkers <= 0 {
+		err := fmt.Errorf("the number of ingest workers must be positive, got %d", flags.StorageIngestWorkers)
+		level.Error(logger).Log("msg", "invalid storage configuration", "err", err)
+		return err
+	}
+
+	if flags.StorageIngestQueueSize < 0 {
+		err := fmt.Errorf("the ingest queue size must not be negative, got %d", flags.StorageIngestQueueSize)
+		level.Error(logger).Log("msg", "invalid storage configuration", "err", err)
+		return err
+	}
+
 	metastore := metastore.NewInProcessClient(mStr)
 
+	bucketCfg, err := yaml.Marshal(cfg.DebugInfo.Bucket)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to marshal debuginfo bucket config", "err", err)
+		return err
+	}
+
+	bucket, err := client.NewBucket(logger, bucketCfg, "parca")
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to initialize debuginfo object store bucket", "err", err)
+		return err
+	}
+
 	col := frostdb.New(
 		reg,
 		flags.StorageGranuleSize,
 		flags.StorageActiveMemory,
 	)
+	var storageBucket objstore.Bucket
+	if flags.StoragePath != "" {
+		if flags.StorageBucket {
+			storageBucket = bucket
+			col = col.WithStoragePath(flags.StoragePath)
+		} else {
+			storageBucket, err = filesystem.NewBucket(flags.StoragePath)
+			if err != nil {
+				level.Error(logger).Log("msg", "failed to initialize storage bucket", "err", err, "path", flags.StoragePath)
+				return err
+			}
+		}
+		col = col.WithStorageBucket(storageBucket)
+	}
 	colDB, err := col.DB("parca")
 	if err != nil {
 		level.Error(logger).Log("msg", "failed to load database", "err", err)
@@ -174,29 +257,45 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
+	var tableBucket objstore.Bucket
+	if storageBucket != nil {
+		tableBucket = frostdb.NewPrefixedBucket(storageBucket, colDB.StorePath())
+	}
+
+	var wal *profilestore.WAL
+	if flags.StorageWALPath != "" {
+		wal, err = profilestore.OpenWAL(flags.StorageWALPath)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to open wal", "err", err, "path", flags.StorageWALPath)
+			return err
+		}
+		defer wal.Close()
+	}
+
 	s := profilestore.NewProfileColumnStore(
 		logger,
 		tracerProvider.Tracer("profilestore"),
 		metastore,
 		table,
 		flags.StorageDebugValueLog,
+		wal,
+		flags.StorageIngestWorkers,
+		flags.StorageIngestQueueSize,
+	)
+	if err := s.ReplayWAL(ctx); err != nil {
+		level.Error(logger).Log("msg", "failed to replay wal", "err", err)
+		return err
+	}
+
+	otlpReceiver := profilestore.NewOTLPReceiver(
+		logger,
+		tracerProvider.Tracer("otlp"),
+		s,
 	)
 	conn, err := grpc.Dial(flags.ProfileShareServer, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
 	if err != nil {
 		return fmt.Errorf("failed to create gRPC connection to ProfileShareServer: %s, %w", flags.ProfileShareServer, err)
 	}
-	q := queryservice.NewColumnQueryAPI(
-		logger,
-		tracerProvider.Tracer("query-service"),
-		metastore,
-		sharepb.NewShareClient(conn),
-		query.NewEngine(
-			memory.DefaultAllocator,
-			colDB.TableProvider(),
-		),
-		"stacktraces",
-	)
-
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	discoveryManager := discovery.NewManager(ctx, logger)
@@ -221,18 +320,6 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
-	bucketCfg, err := yaml.Marshal(cfg.DebugInfo.Bucket)
-	if err != nil {
-		level.Error(logger).Log("msg", "failed to marshal debuginfo bucket config", "err", err)
-		return err
-	}
-
-	bucket, err := client.NewBucket(logger, bucketCfg, "parca")
-	if err != nil {
-		level.Error(logger).Log("msg", "failed to initialize debuginfo object store bucket", "err", err)
-		return err
-	}
-
 	var debugInfodClient debuginfo.DebugInfodClient = debuginfo.NopDebugInfodClient{}
 	if len(flags.DebugInfodUpstreamServers) > 0 {
 		httpDebugInfoClient, err := debuginfo.NewHTTPDebugInfodClient(logger, flags.DebugInfodUpstreamServers, flags.DebugInfodHTTPRequestTimeout)
@@ -267,6 +354,19 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		return err
 	}
 
+	q := queryservice.NewColumnQueryAPI(
+		logger,
+		tracerProvider.Tracer("query-service"),
+		metastore,
+		sharepb.NewShareClient(conn),
+		dbgInfo,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			parcacol.NewBlockTableProvider(logger, colDB, tableBucket),
+		),
+		"stacktraces",
+	)
+
 	reloaders := []config.ComponentReloader{
 		{
 			Name: "scrape_sd",
@@ -294,6 +394,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		s := symbolizer.New(
 			logger,
 			metastore,
+			mStr,
 			dbgInfo,
 			sym,
 			debugInfoCache.Directory,
@@ -310,6 +411,73 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				sym.Close()
 			})
 	}
+	if flags.Metastore == metaStoreBadger {
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return mStr.RunValueLogGC(ctx, metastoreValueLogGCInterval)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "metastore shutting down")
+				cancel()
+			},
+		)
+	}
+	if wal != nil {
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return s.RunWALCheckpoints(ctx)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "wal checkpoints exiting")
+				cancel()
+			},
+		)
+	}
+	// Retention and downsampling both replace persisted blocks.
+	var blocksMtx sync.Mutex
+	if flags.StorageRetention > 0 {
+		r := parcacol.NewRetention(
+			logger,
+			tableBucket,
+			"stacktraces",
+			table,
+			mStr,
+			time.Duration(flags.StorageRetention),
+			&blocksMtx,
+		)
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return r.Run(ctx, retentionInterval)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "retention exiting")
+				cancel()
+			},
+		)
+	}
+	if len(downsamplingTiers) > 0 {
+		d := parcacol.NewDownsampler(
+			logger,
+			tableBucket,
+			"stacktraces",
+			parcacol.Schema(),
+			downsamplingTiers,
+			&blocksMtx,
+		)
+		ctx, cancel := context.WithCancel(ctx)
+		gr.Add(
+			func() error {
+				return d.Run(ctx, downsamplingInterval)
+			},
+			func(_ error) {
+				level.Debug(logger).Log("msg", "downsampler exiting")
+				cancel()
+			},
+		)
+	}
 	gr.Add(
 		func() error {
 			return discoveryManager.Run()
@@ -338,6 +506,9 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 		},
 	)
 	parcaserver := server.NewServer(reg, version)
+	parcaserver.Handle(profilestore.OTLPProfilesPath, otlpReceiver)
+	parcaserver.Handle(profilestore.FoldedPath, profilestore.NewFoldedHandler(logger, s))
+	parcaserver.Handle(profilestore.JFRPath, profilestore.NewJFRHandler(logger, s))
 	gr.Add(
 		func() error {
 			return parcaserver.ListenAndServe(
@@ -349,6 +520,7 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 				server.RegisterableFunc(func(ctx context.Context, srv *grpc.Server, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
 					debuginfopb.RegisterDebugInfoServiceServer(srv, dbgInfo)
 					profilestorepb.RegisterProfileStoreServiceServer(srv, s)
+					profilestore.RegisterOTLPProfilesServiceServer(srv, otlpReceiver)
 					querypb.RegisterQueryServiceServer(srv, q)
 					scrapepb.RegisterScrapeServiceServer(srv, m)
 
@@ -383,7 +555,29 @@ func Run(ctx context.Context, logger log.Logger, reg *prometheus.Registry, flags
 			}
 		},
 	)
-	if err := gr.Run(); err != nil {
+	err = gr.Run()
+
+	// The metastore is closed only once all components writing to it have
+	// exited.
+	defer func() {
+		if err := mStr.Close(); err != nil {
+			level.Error(logger).Log("msg", "failed to close metastore", "err", err)
+		}
+	}()
+
+	if wal != nil {
+		level.Debug(logger).Log("msg", "checkpointing wal")
+		if err := s.CheckpointWAL(); err != nil {
+			level.Error(logger).Log("msg", "failed to checkpoint wal", "err", err)
+		}
+	} else if storageBucket != nil {
+		level.Debug(logger).Log("msg", "persisting active storage block")
+		if err := parcacol.PersistActiveBlock(table); err != nil {
+			level.Error(logger).Log("msg", "failed to persist active storage block", "err", err)
+		}
+	}
+
+	if err != nil {
 		if _, ok := err.(run.SignalError); ok {
 			return nil
 		}
@@ -42,6 +42,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
@@ -239,6 +240,7 @@ func replayDebugLog(ctx context.Context, t Testing) (querypb.QueryServiceServer,
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -389,6 +391,7 @@ func TestConsistency(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -413,3 +416,23 @@ func TestConsistency(t *testing.T) {
 
 	require.Equal(t, len(compactedOriginalProfile.Sample), len(resProf.Sample))
 }
+
+// TestOTLPProtos checks that the vendored OTLP profiles protos are linked
+// together with the OTLP protos of the trace exporter, registering the same
+// proto file twice panics when the binary starts.
+func TestOTLPProtos(t *testing.T) {
+	fd, err := protoregistry.GlobalFiles.FindFileByPath("parca/otlp/profiles/v1development/profiles.proto")
+	require.NoError(t, err)
+
+	imports := []string{}
+	for i := 0; i < fd.Imports().Len(); i++ {
+		imports = append(imports, fd.Imports().Get(i).Path())
+	}
+	require.Equal(t, []string{
+		"opentelemetry/proto/common/v1/common.proto",
+		"opentelemetry/proto/resource/v1/resource.proto",
+	}, imports)
+
+	_, err = protoregistry.GlobalFiles.FindFileByPath("opentelemetry/proto/collector/trace/v1/trace_service.proto")
+	require.NoError(t, err)
+}
@@ -16,6 +16,9 @@ package parcacol
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/apache/arrow/go/v8/arrow"
 	"github.com/apache/arrow/go/v8/arrow/array"
@@ -44,10 +47,26 @@ func ArrowRecordToStacktraceSamples(
 	}
 	valueColumn := ar.Column(indices[0]).(*array.Int64)
 
+	labelColumns, err := readPprofLabelColumns(ar)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows of the same stacktrace with different pprof labels share the
+	// stacktrace, which is resolved once.
 	rows := int(ar.NumRows())
-	stacktraceIDs := make([]string, rows)
+	stacktraceIDs := make([]string, 0, rows)
+	stacktraceIndex := make(map[string]int, rows)
+	rowStacktraces := make([]int, rows)
 	for i := 0; i < rows; i++ {
-		stacktraceIDs[i] = string(stacktraceColumn.Value(i))
+		id := string(stacktraceColumn.Value(i))
+		j, ok := stacktraceIndex[id]
+		if !ok {
+			j = len(stacktraceIDs)
+			stacktraceIndex[id] = j
+			stacktraceIDs = append(stacktraceIDs, id)
+		}
+		rowStacktraces[i] = j
 	}
 
 	stacktraceLocations, err := resolveStacktraces(ctx, m, stacktraceIDs)
@@ -57,9 +76,12 @@ func ArrowRecordToStacktraceSamples(
 
 	samples := make([]*profile.SymbolizedSample, 0, rows)
 	for i := 0; i < rows; i++ {
+		sampleLabels, sampleNumLabels := labelColumns.labels(i)
 		samples = append(samples, &profile.SymbolizedSample{
 			Value:     valueColumn.Value(i),
-			Locations: stacktraceLocations[i],
+			Locations: stacktraceLocations[rowStacktraces[i]],
+			Label:     sampleLabels,
+			NumLabel:  sampleNumLabels,
 		})
 	}
 
@@ -69,6 +91,228 @@ func ArrowRecordToStacktraceSamples(
 	}, nil
 }
 
+// ArrowRecordsToDiffStacktraceSamples joins the samples of the base and
+// compare records by their stacktrace ID and pprof labels, and resolves each
+// stacktrace only once. The value of each sample is the value of the compare
+// record and the diff value is the difference to the base record. Samples only
+// found in the base record have a value of zero and a negative diff value.
+func ArrowRecordsToDiffStacktraceSamples(
+	ctx context.Context,
+	m pb.MetastoreServiceClient,
+	base arrow.Record,
+	compare arrow.Record,
+	valueColumnName string,
+	meta profile.Meta,
+) (*profile.Profile, error) {
+	// Samples of the same stacktrace with different pprof labels share the
+	// stacktrace, which is resolved once.
+	stacktraceIDs := []string{}
+	stacktraceIndex := map[string]int{}
+	sampleStacktraces := []int{}
+	index := map[string]int{}
+	samples := []*profile.SymbolizedSample{}
+	sample := func(s stacktraceValue) *profile.SymbolizedSample {
+		key := s.key()
+		i, ok := index[key]
+		if !ok {
+			i = len(samples)
+			index[key] = i
+			j, ok := stacktraceIndex[s.id]
+			if !ok {
+				j = len(stacktraceIDs)
+				stacktraceIndex[s.id] = j
+				stacktraceIDs = append(stacktraceIDs, s.id)
+			}
+			sampleStacktraces = append(sampleStacktraces, j)
+			samples = append(samples, &profile.SymbolizedSample{
+				Label:    s.label,
+				NumLabel: s.numLabel,
+			})
+		}
+		return samples[i]
+	}
+
+	err := forEachStacktraceValue(compare, valueColumnName, func(s stacktraceValue) {
+		sample := sample(s)
+		sample.Value += s.value
+		sample.DiffValue += s.value
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read compare record: %w", err)
+	}
+
+	err = forEachStacktraceValue(base, valueColumnName, func(s stacktraceValue) {
+		sample(s).DiffValue -= s.value
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read base record: %w", err)
+	}
+
+	if len(samples) == 0 {
+		return &profile.Profile{Meta: meta}, nil
+	}
+
+	stacktraceLocations, err := resolveStacktraces(ctx, m, stacktraceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("read stacktrace metadata: %w", err)
+	}
+
+	for i, sample := range samples {
+		sample.Locations = stacktraceLocations[sampleStacktraces[i]]
+	}
+
+	return &profile.Profile{
+		Samples: samples,
+		Meta:    meta,
+	}, nil
+}
+
+// stacktraceValue is the value of a stacktrace with a set of pprof labels.
+type stacktraceValue struct {
+	id       string
+	value    int64
+	label    map[string]string
+	numLabel map[string]int64
+}
+
+// key returns the stacktrace ID and pprof labels of the value as a string.
+func (s stacktraceValue) key() string {
+	labelNames := make([]string, 0, len(s.label))
+	for name := range s.label {
+		labelNames = append(labelNames, name)
+	}
+	sort.Strings(labelNames)
+
+	numLabelNames := make([]string, 0, len(s.numLabel))
+	for name := range s.numLabel {
+		numLabelNames = append(numLabelNames, name)
+	}
+	sort.Strings(numLabelNames)
+
+	var b strings.Builder
+	b.WriteString(s.id)
+	for _, name := range labelNames {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteString("\x00")
+		b.WriteString(s.label[name])
+	}
+	b.WriteString("\x01")
+	for _, name := range numLabelNames {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteString("\x00")
+		b.WriteString(strconv.FormatInt(s.numLabel[name], 10))
+	}
+
+	return b.String()
+}
+
+// forEachStacktraceValue calls f with the stacktrace ID, value and pprof
+// labels of each row of the record. Records without rows may not have any
+// columns.
+func forEachStacktraceValue(ar arrow.Record, valueColumnName string, f func(stacktraceValue)) error {
+	if ar == nil || ar.NumRows() == 0 {
+		return nil
+	}
+
+	schema := ar.Schema()
+	indices := schema.FieldIndices("stacktrace")
+	if len(indices) != 1 {
+		return fmt.Errorf("expected exactly one stacktrace column, got %d", len(indices))
+	}
+	stacktraceColumn, ok := ar.Column(indices[0]).(*array.Binary)
+	if !ok {
+		return fmt.Errorf("expected stacktrace column to be binary, got %T", ar.Column(indices[0]))
+	}
+
+	indices = schema.FieldIndices(valueColumnName)
+	if len(indices) != 1 {
+		return fmt.Errorf("expected exactly one value column, got %d", len(indices))
+	}
+	valueColumn, ok := ar.Column(indices[0]).(*array.Int64)
+	if !ok {
+		return fmt.Errorf("expected value column to be int64, got %T", ar.Column(indices[0]))
+	}
+
+	labelColumns, err := readPprofLabelColumns(ar)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(ar.NumRows()); i++ {
+		label, numLabel := labelColumns.labels(i)
+		f(stacktraceValue{
+			id:       string(stacktraceColumn.Value(i)),
+			value:    valueColumn.Value(i),
+			label:    label,
+			numLabel: numLabel,
+		})
+	}
+
+	return nil
+}
+
+// pprofLabelColumns are the pprof label columns of a record. They are only
+// present if the query aggregated by them.
+type pprofLabelColumns struct {
+	labelNames      []string
+	labelColumns    []*array.Binary
+	numLabelNames   []string
+	numLabelColumns []*array.Int64
+}
+
+func readPprofLabelColumns(ar arrow.Record) (pprofLabelColumns, error) {
+	res := pprofLabelColumns{}
+	for i, field := range ar.Schema().Fields() {
+		switch {
+		case strings.HasPrefix(field.Name, ColumnPprofLabels+"."):
+			col, ok := ar.Column(i).(*array.Binary)
+			if !ok {
+				return res, fmt.Errorf("expected pprof label column %s to be binary, got %T", field.Name, ar.Column(i))
+			}
+			res.labelNames = append(res.labelNames, strings.TrimPrefix(field.Name, ColumnPprofLabels+"."))
+			res.labelColumns = append(res.labelColumns, col)
+		case strings.HasPrefix(field.Name, ColumnPprofNumLabels+"."):
+			col, ok := ar.Column(i).(*array.Int64)
+			if !ok {
+				return res, fmt.Errorf("expected pprof num label column %s to be int64, got %T", field.Name, ar.Column(i))
+			}
+			res.numLabelNames = append(res.numLabelNames, strings.TrimPrefix(field.Name, ColumnPprofNumLabels+"."))
+			res.numLabelColumns = append(res.numLabelColumns, col)
+		}
+	}
+
+	return res, nil
+}
+
+// labels returns the pprof labels of the row, or nil if it has none.
+func (c pprofLabelColumns) labels(i int) (map[string]string, map[string]int64) {
+	var label map[string]string
+	for j, col := range c.labelColumns {
+		if col.IsNull(i) {
+			continue
+		}
+		if label == nil {
+			label = map[string]string{}
+		}
+		label[c.labelNames[j]] = string(col.Value(i))
+	}
+
+	var numLabel map[string]int64
+	for j, col := range c.numLabelColumns {
+		if col.IsNull(i) {
+			continue
+		}
+		if numLabel == nil {
+			numLabel = map[string]int64{}
+		}
+		numLabel[c.numLabelNames[j]] = col.Value(i)
+	}
+
+	return label, numLabel
+}
+
 func SymbolizeNormalizedProfile(ctx context.Context, m pb.MetastoreServiceClient, p *profile.NormalizedProfile) (*profile.Profile, error) {
 	stacktraceIDs := make([]string, len(p.Samples))
 	for i, sample := range p.Samples {
@@ -86,6 +330,8 @@ func SymbolizeNormalizedProfile(ctx context.Context, m pb.MetastoreServiceClient
 			Value:     sample.Value,
 			DiffValue: sample.DiffValue,
 			Locations: stacktraceLocations[i],
+			Label:     sample.Label,
+			NumLabel:  sample.NumLabel,
 		}
 	}
 
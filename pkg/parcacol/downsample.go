@@ -0,0 +1,418 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/oklog/ulid"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/segmentio/parquet-go"
+	"github.com/thanos-io/objstore"
+)
+
+// DownsamplingTier configures the resolution samples are downsampled to once
+// they are older than the age of the tier.
+type DownsamplingTier struct {
+	Age        time.Duration
+	Resolution time.Duration
+}
+
+// ParseDownsamplingTiers parses tiers in the form of <age>:<resolution>, for
+// example 7d:5m. The tiers are returned sorted by their age.
+func ParseDownsamplingTiers(tiers []string) ([]DownsamplingTier, error) {
+	res := make([]DownsamplingTier, 0, len(tiers))
+	for _, t := range tiers {
+		parts := strings.Split(t, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid downsampling tier %q, expected <age>:<resolution>", t)
+		}
+
+		age, err := model.ParseDuration(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid age of downsampling tier %q: %w", t, err)
+		}
+
+		resolution, err := model.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid resolution of downsampling tier %q: %w", t, err)
+		}
+		if resolution <= 0 {
+			return nil, fmt.Errorf("invalid resolution of downsampling tier %q: must be positive", t)
+		}
+
+		res = append(res, DownsamplingTier{
+			Age:        time.Duration(age),
+			Resolution: time.Duration(resolution),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Age < res[j].Age
+	})
+
+	for i := 1; i < len(res); i++ {
+		if res[i].Resolution < res[i-1].Resolution {
+			return nil, fmt.Errorf("downsampling tier %s:%s is finer than a younger tier", model.Duration(res[i].Age), model.Duration(res[i].Resolution))
+		}
+	}
+
+	return res, nil
+}
+
+// Downsampler merges the samples of persisted blocks into coarser resolutions
+// once they are old enough. Samples that only differ in their timestamp within
+// the same resolution window are merged into a single sample at the start of
+// the window, summing their values and durations. Blocks are downsampled as a
+// whole, once their newest sample is older than the age of a tier.
+type Downsampler struct {
+	logger    log.Logger
+	bucket    objstore.Bucket
+	tableName string
+	schema    *dynparquet.Schema
+	tiers     []DownsamplingTier
+	blocksMtx sync.Locker
+}
+
+// NewDownsampler returns a new Downsampler for the blocks of the table
+// persisted in the bucket. The blocks lock is held while blocks are
+// downsampled, it is to be shared with the retention of the table so that
+// they do not modify the same blocks concurrently.
+func NewDownsampler(
+	logger log.Logger,
+	bucket objstore.Bucket,
+	tableName string,
+	schema *dynparquet.Schema,
+	tiers []DownsamplingTier,
+	blocksMtx sync.Locker,
+) *Downsampler {
+	return &Downsampler{
+		logger:    logger,
+		bucket:    bucket,
+		tableName: tableName,
+		schema:    schema,
+		tiers:     tiers,
+		blocksMtx: blocksMtx,
+	}
+}
+
+// Run downsamples the blocks every interval until the context is canceled.
+func (d *Downsampler) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.Downsample(ctx); err != nil {
+				level.Error(d.logger).Log("msg", "failed to downsample blocks", "err", err)
+			}
+		}
+	}
+}
+
+// Downsample downsamples all blocks that are old enough to the resolution of
+// the oldest tier they fall into.
+func (d *Downsampler) Downsample(ctx context.Context) error {
+	blockDirs := []string{}
+	err := d.bucket.Iter(ctx, d.tableName, func(dir string) error {
+		blockDirs = append(blockDirs, dir)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("list blocks: %w", err)
+	}
+
+	now := time.Now()
+	for _, dir := range blockDirs {
+		if err := d.downsampleBlock(ctx, now, dir); err != nil {
+			return fmt.Errorf("downsample block %q: %w", dir, err)
+		}
+	}
+
+	return nil
+}
+
+// downsampleBlock replaces the block with its downsampled version, if it is old
+// enough. Whether it is, is decided from its footer, so that only the blocks
+// that are downsampled are downloaded. The downsampled block is uploaded before
+// the block is deleted, and its name is derived from the block and the
+// resolution, so that after a failure in between, the next attempt finds it and
+// only deletes the block. The blocks lock is only held for the block, which
+// may have been deleted by the retention since the blocks were listed.
+func (d *Downsampler) downsampleBlock(ctx context.Context, now time.Time, dir string) error {
+	d.blocksMtx.Lock()
+	defer d.blocksMtx.Unlock()
+
+	blockID, err := ulid.Parse(path.Base(dir))
+	if err != nil {
+		return err
+	}
+
+	name := path.Join(dir, blockFileName)
+	attrs, err := d.bucket.Attributes(ctx, name)
+	if d.bucket.IsObjNotFoundErr(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	footer, err := parquet.OpenFile(
+		bucketReaderAt{ctx: ctx, bucket: d.bucket, name: name},
+		attrs.Size,
+		parquet.SkipBloomFilters(true),
+	)
+	if err != nil {
+		// The block may still be being uploaded, it is read again by the
+		// next run.
+		level.Debug(d.logger).Log("msg", "failed to read footer of block", "block", blockID, "err", err)
+		return nil
+	}
+
+	max := maxTimestamp(footer)
+	var resolution time.Duration
+	for _, tier := range d.tiers {
+		if max < timestamp.FromTime(now.Add(-tier.Age)) {
+			resolution = tier.Resolution
+		}
+	}
+	if resolution == 0 || isDownsampledBlockID(blockID, resolution) {
+		return nil
+	}
+
+	newBlockID, err := downsampledBlockID(blockID, resolution)
+	if err != nil {
+		return err
+	}
+	newName := path.Join(d.tableName, newBlockID.String(), blockFileName)
+	uploaded, err := d.isUploaded(ctx, newName)
+	if err != nil {
+		return err
+	}
+	if uploaded {
+		// The block was downsampled before, but not deleted.
+		return d.bucket.Delete(ctx, name)
+	}
+
+	rc, err := d.bucket.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return err
+	}
+
+	block, err := dynparquet.ReaderFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	rows, err := readRows(block.ParquetFile())
+	if err != nil {
+		return err
+	}
+
+	downsampled, err := downsampleRows(block.ParquetFile().Schema(), rows, resolution)
+	if err != nil {
+		return err
+	}
+	if len(downsampled) == len(rows) {
+		// The block already is at the resolution.
+		return nil
+	}
+
+	buf, err := d.schema.NewBuffer(block.DynamicColumns())
+	if err != nil {
+		return err
+	}
+	if _, err := buf.WriteRows(downsampled); err != nil {
+		return err
+	}
+	buf.Sort()
+
+	serialized, err := d.schema.SerializeBuffer(buf)
+	if err != nil {
+		return err
+	}
+
+	if err := d.bucket.Upload(ctx, newName, bytes.NewReader(serialized)); err != nil {
+		return err
+	}
+
+	if err := d.bucket.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	level.Debug(d.logger).Log("msg", "downsampled block", "block", blockID, "new_block", newBlockID, "resolution", resolution, "rows_before", len(rows), "rows_after", len(downsampled))
+	return nil
+}
+
+// isUploaded returns whether the block exists and was uploaded completely,
+// which an upload interrupted by a crash may not have.
+func (d *Downsampler) isUploaded(ctx context.Context, name string) (bool, error) {
+	exists, err := d.bucket.Exists(ctx, name)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	attrs, err := d.bucket.Attributes(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	// The footer is written last.
+	_, err = parquet.OpenFile(
+		bucketReaderAt{ctx: ctx, bucket: d.bucket, name: name},
+		attrs.Size,
+		parquet.SkipPageIndex(true),
+		parquet.SkipBloomFilters(true),
+	)
+	return err == nil, nil
+}
+
+// downsampledBlockID returns the ID of the block the block is downsampled to
+// at the resolution. It keeps the time of the block it replaces, as blocks
+// newer than the active block of the table are not read. The first 6 bytes of
+// its entropy are derived from the block and the resolution, and the last 4
+// bytes from the first 6 and the resolution, so that it is recognized as the
+// result of downsampling to the resolution by isDownsampledBlockID.
+func downsampledBlockID(blockID ulid.ULID, resolution time.Duration) (ulid.ULID, error) {
+	h := sha256.New()
+	h.Write(blockID[:])
+	binary.Write(h, binary.BigEndian, int64(resolution))
+
+	entropy := make([]byte, 10)
+	copy(entropy, h.Sum(nil)[:6])
+	copy(entropy[6:], resolutionTag(entropy[:6], resolution))
+	return ulid.New(blockID.Time(), bytes.NewReader(entropy))
+}
+
+// isDownsampledBlockID returns whether the block is the result of downsampling
+// a block to the resolution.
+func isDownsampledBlockID(blockID ulid.ULID, resolution time.Duration) bool {
+	entropy := blockID.Entropy()
+	return bytes.Equal(entropy[6:], resolutionTag(entropy[:6], resolution))
+}
+
+func resolutionTag(entropy []byte, resolution time.Duration) []byte {
+	h := sha256.New()
+	h.Write(entropy)
+	binary.Write(h, binary.BigEndian, int64(resolution))
+	return h.Sum(nil)[:4]
+}
+
+// readRows reads all rows of the file.
+func readRows(f *parquet.File) ([]parquet.Row, error) {
+	res := make([]parquet.Row, 0, f.NumRows())
+	buf := make([]parquet.Row, 64)
+	for _, rg := range f.RowGroups() {
+		rows := rg.Rows()
+		for {
+			n, err := rows.ReadRows(buf)
+			for _, row := range buf[:n] {
+				res = append(res, row.Clone())
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+		}
+		rows.Close()
+	}
+
+	return res, nil
+}
+
+// downsampleRows aligns the timestamps of the rows to the resolution and
+// merges the rows that are equal apart from their value and duration.
+func downsampleRows(schema *parquet.Schema, rows []parquet.Row, resolution time.Duration) ([]parquet.Row, error) {
+	timestampIndex := columnIndex(schema, ColumnTimestamp)
+	valueIndex := columnIndex(schema, ColumnValue)
+	durationIndex := columnIndex(schema, ColumnDuration)
+	if timestampIndex == -1 || valueIndex == -1 || durationIndex == -1 {
+		return nil, errors.New("timestamp, value or duration column not found")
+	}
+
+	window := resolution.Milliseconds()
+	res := make([]parquet.Row, 0, len(rows))
+	merged := map[string]parquet.Row{}
+	key := []byte{}
+	for _, row := range rows {
+		ts := row[timestampIndex]
+		row[timestampIndex] = parquet.ValueOf(ts.Int64()-ts.Int64()%window).Level(ts.RepetitionLevel(), ts.DefinitionLevel(), timestampIndex)
+
+		key = key[:0]
+		for i, v := range row {
+			if i == valueIndex || i == durationIndex {
+				continue
+			}
+			if v.IsNull() {
+				key = append(key, 0)
+				continue
+			}
+			b := v.Bytes()
+			key = append(key, 1, 0, 0, 0, 0)
+			binary.BigEndian.PutUint32(key[len(key)-4:], uint32(len(b)))
+			key = append(key, b...)
+		}
+
+		existing, ok := merged[string(key)]
+		if !ok {
+			merged[string(key)] = row
+			res = append(res, row)
+			continue
+		}
+
+		existing[valueIndex] = sumValues(existing[valueIndex], row[valueIndex], valueIndex)
+		existing[durationIndex] = sumValues(existing[durationIndex], row[durationIndex], durationIndex)
+	}
+
+	return res, nil
+}
+
+func sumValues(a, b parquet.Value, columnIndex int) parquet.Value {
+	return parquet.ValueOf(a.Int64()+b.Int64()).Level(a.RepetitionLevel(), a.DefinitionLevel(), columnIndex)
+}
+
+func columnIndex(schema *parquet.Schema, name string) int {
+	for i, field := range schema.Fields() {
+		if field.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
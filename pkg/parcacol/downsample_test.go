@@ -0,0 +1,315 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/oklog/ulid"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/segmentio/parquet-go"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestParseDownsamplingTiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tiers   []string
+		want    []DownsamplingTier
+		wantErr bool
+	}{{
+		name:  "none",
+		tiers: []string{},
+		want:  []DownsamplingTier{},
+	}, {
+		name:  "sorted by age",
+		tiers: []string{"7d:5m", "2d:1m"},
+		want: []DownsamplingTier{
+			{Age: 48 * time.Hour, Resolution: time.Minute},
+			{Age: 7 * 24 * time.Hour, Resolution: 5 * time.Minute},
+		},
+	}, {
+		name:    "missing resolution",
+		tiers:   []string{"7d"},
+		wantErr: true,
+	}, {
+		name:    "invalid age",
+		tiers:   []string{"a:1m"},
+		wantErr: true,
+	}, {
+		name:    "invalid resolution",
+		tiers:   []string{"7d:a"},
+		wantErr: true,
+	}, {
+		name:    "zero resolution",
+		tiers:   []string{"7d:0s"},
+		wantErr: true,
+	}, {
+		name:    "older tier finer than younger tier",
+		tiers:   []string{"2d:5m", "7d:1m"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseDownsamplingTiers(tt.tiers)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type testSample struct {
+	timestamp  int64
+	stacktrace string
+	value      int64
+	duration   int64
+}
+
+// testBlock returns a serialized block of the samples of a single series.
+func testBlock(t *testing.T, samples []testSample) []byte {
+	t.Helper()
+
+	schema := Schema()
+	buf, err := schema.NewBuffer(map[string][]string{
+		ColumnLabels:         {"job"},
+		ColumnPprofLabels:    {},
+		ColumnPprofNumLabels: {},
+	})
+	require.NoError(t, err)
+
+	ls := labels.Labels{{Name: "job", Value: "default"}}
+	for _, s := range samples {
+		meta := profile.Meta{Name: "memory", Timestamp: s.timestamp, Duration: s.duration}
+		row := SampleToParquetRow(schema, nil, nil, nil, "", ls, meta, &profile.NormalizedSample{
+			StacktraceID: s.stacktrace,
+			Value:        s.value,
+		})
+		_, err := buf.WriteRows([]parquet.Row{row})
+		require.NoError(t, err)
+	}
+	buf.Sort()
+
+	data, err := schema.SerializeBuffer(buf)
+	require.NoError(t, err)
+	return data
+}
+
+// blockRows returns the schema and the rows of a serialized block.
+func blockRows(t *testing.T, data []byte) (*parquet.Schema, []parquet.Row) {
+	t.Helper()
+
+	block, err := dynparquet.ReaderFromBytes(data)
+	require.NoError(t, err)
+	rows, err := readRows(block.ParquetFile())
+	require.NoError(t, err)
+	return block.ParquetFile().Schema(), rows
+}
+
+// rowSamples returns the samples of the rows, sorted by their timestamp and
+// stacktrace.
+func rowSamples(schema *parquet.Schema, rows []parquet.Row) []testSample {
+	res := make([]testSample, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, testSample{
+			timestamp:  row[columnIndex(schema, ColumnTimestamp)].Int64(),
+			stacktrace: string(row[columnIndex(schema, ColumnStacktrace)].ByteArray()),
+			value:      row[columnIndex(schema, ColumnValue)].Int64(),
+			duration:   row[columnIndex(schema, ColumnDuration)].Int64(),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].timestamp == res[j].timestamp {
+			return res[i].stacktrace < res[j].stacktrace
+		}
+		return res[i].timestamp < res[j].timestamp
+	})
+	return res
+}
+
+func TestDownsampleRows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		samples    []testSample
+		resolution time.Duration
+		want       []testSample
+	}{{
+		name: "merged within window",
+		samples: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 1, duration: 10},
+			{timestamp: 90_000, stacktrace: "a", value: 2, duration: 10},
+		},
+		resolution: time.Minute,
+		want: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 3, duration: 20},
+		},
+	}, {
+		name: "aligned to the start of the window",
+		samples: []testSample{
+			{timestamp: 61_000, stacktrace: "a", value: 1, duration: 10},
+		},
+		resolution: time.Minute,
+		want: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 1, duration: 10},
+		},
+	}, {
+		name: "different windows",
+		samples: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 1, duration: 10},
+			{timestamp: 120_000, stacktrace: "a", value: 2, duration: 10},
+		},
+		resolution: time.Minute,
+		want: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 1, duration: 10},
+			{timestamp: 120_000, stacktrace: "a", value: 2, duration: 10},
+		},
+	}, {
+		name: "different stacktraces",
+		samples: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 1, duration: 10},
+			{timestamp: 90_000, stacktrace: "b", value: 2, duration: 10},
+		},
+		resolution: time.Minute,
+		want: []testSample{
+			{timestamp: 60_000, stacktrace: "a", value: 1, duration: 10},
+			{timestamp: 60_000, stacktrace: "b", value: 2, duration: 10},
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schema, rows := blockRows(t, testBlock(t, tt.samples))
+			got, err := downsampleRows(schema, rows, tt.resolution)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, rowSamples(schema, got))
+		})
+	}
+}
+
+func TestDownsampleRows_MissingColumns(t *testing.T) {
+	t.Parallel()
+
+	_, err := downsampleRows(parquet.SchemaOf(struct{ Value int64 }{}), nil, time.Minute)
+	require.Error(t, err)
+}
+
+// getCountingBucket counts the objects downloaded in full.
+type getCountingBucket struct {
+	*objstore.InMemBucket
+	gets int
+}
+
+func (b *getCountingBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	b.gets++
+	return b.InMemBucket.Get(ctx, name)
+}
+
+func TestDownsampler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	bucket := &getCountingBucket{InMemBucket: objstore.NewInMemBucket()}
+
+	now := time.Now()
+	old := timestamp.FromTime(now.Add(-2 * time.Hour))
+	old -= old % time.Minute.Milliseconds()
+	blockID := ulid.MustNew(ulid.Timestamp(now.Add(-2*time.Hour)), bytes.NewReader(make([]byte, 16)))
+	block := path.Join("stacktraces", blockID.String(), blockFileName)
+	data := testBlock(t, []testSample{
+		{timestamp: old, stacktrace: "a", value: 1, duration: 10},
+		{timestamp: old + 1000, stacktrace: "a", value: 2, duration: 10},
+	})
+	require.NoError(t, bucket.Upload(ctx, block, bytes.NewReader(data)))
+
+	d := NewDownsampler(
+		log.NewNopLogger(),
+		bucket,
+		"stacktraces",
+		Schema(),
+		[]DownsamplingTier{{Age: time.Hour, Resolution: time.Minute}},
+		&sync.Mutex{},
+	)
+
+	downsampledBlock := func() string {
+		objects := bucket.Objects()
+		require.Len(t, objects, 1)
+		for name := range objects {
+			require.NotEqual(t, block, name)
+			schema, rows := blockRows(t, objects[name])
+			require.Equal(t, []testSample{
+				{timestamp: old, stacktrace: "a", value: 3, duration: 20},
+			}, rowSamples(schema, rows))
+			return name
+		}
+		return ""
+	}
+
+	// Blocks too young to be downsampled are not downloaded.
+	young := ulid.MustNew(ulid.Now(), bytes.NewReader(make([]byte, 16)))
+	youngBlock := path.Join("stacktraces", young.String(), blockFileName)
+	require.NoError(t, bucket.Upload(ctx, youngBlock, bytes.NewReader(testBlock(t, []testSample{
+		{timestamp: timestamp.FromTime(now), stacktrace: "a", value: 1, duration: 10},
+	}))))
+	require.NoError(t, d.Downsample(ctx))
+	require.Equal(t, 1, bucket.gets)
+	require.NoError(t, bucket.Delete(ctx, youngBlock))
+	downsampled := downsampledBlock()
+
+	// Blocks at the resolution are kept as they are, without being downloaded.
+	require.NoError(t, d.Downsample(ctx))
+	require.Equal(t, downsampled, downsampledBlock())
+	require.Equal(t, 1, bucket.gets)
+
+	// The block was downsampled, but failed to be deleted.
+	require.NoError(t, bucket.Upload(ctx, block, bytes.NewReader(data)))
+	require.NoError(t, d.Downsample(ctx))
+	require.Equal(t, downsampled, downsampledBlock())
+	require.Equal(t, 1, bucket.gets)
+
+	// The upload of the downsampled block was interrupted.
+	rc, err := bucket.Get(ctx, downsampled)
+	require.NoError(t, err)
+	partial, err := io.ReadAll(io.LimitReader(rc, 16))
+	require.NoError(t, err)
+	rc.Close()
+	require.NoError(t, bucket.Upload(ctx, downsampled, bytes.NewReader(partial)))
+	require.NoError(t, bucket.Upload(ctx, block, bytes.NewReader(data)))
+	require.NoError(t, d.Downsample(ctx))
+	require.Equal(t, downsampled, downsampledBlock())
+}
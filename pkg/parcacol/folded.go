@@ -0,0 +1,114 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// maxFoldedLineSize is the maximum size of a line of folded stacks, deep
+// stacks of long frames can easily exceed the default of bufio.Scanner.
+const maxFoldedLineSize = 4 * 1024 * 1024
+
+// ErrInvalidFoldedStacks is returned when folded stacks cannot be parsed.
+var ErrInvalidFoldedStacks = errors.New("invalid folded stacks")
+
+// NormalizeFolded parses stacks in the folded format, one per line with its
+// frames separated by semicolons, root first, followed by a space and its
+// value, e.g. main;foo;bar 42. Frames are only identified by their function
+// name, so each frame becomes a function and a location without a mapping.
+// Identical stacks are merged into a single sample.
+func (n *Normalizer) NormalizeFolded(ctx context.Context, meta profile.Meta, folded []byte) (*profile.NormalizedProfile, error) {
+	var (
+		stacks [][]stackFrame
+		values []int64
+	)
+	stackIndex := map[string]int{}
+
+	s := bufio.NewScanner(bytes.NewReader(folded))
+	s.Buffer(nil, maxFoldedLineSize)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		i := strings.LastIndexByte(text, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("%w: line %d: missing value", ErrInvalidFoldedStacks, line)
+		}
+		stack, value := strings.TrimSpace(text[:i]), text[i+1:]
+
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid value %q", ErrInvalidFoldedStacks, line, value)
+		}
+		if v == 0 {
+			continue
+		}
+
+		if j, ok := stackIndex[stack]; ok {
+			values[j] += v
+			continue
+		}
+
+		// Stacktraces are stored leaf first, while folded stacks are root
+		// first.
+		functions := strings.Split(stack, ";")
+		frames := make([]stackFrame, len(functions))
+		for j, function := range functions {
+			if function == "" {
+				return nil, fmt.Errorf("%w: line %d: empty frame", ErrInvalidFoldedStacks, line)
+			}
+			frames[len(functions)-1-j] = stackFrame{function: function}
+		}
+
+		stackIndex[stack] = len(stacks)
+		stacks = append(stacks, frames)
+		values = append(values, v)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFoldedStacks, err)
+	}
+
+	np := &profile.NormalizedProfile{
+		Meta:    meta,
+		Samples: make([]*profile.NormalizedSample, 0, len(stacks)),
+	}
+	if len(stacks) == 0 {
+		return np, nil
+	}
+
+	ids, err := n.getOrCreateStacktraces(ctx, stacks)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		np.Samples = append(np.Samples, &profile.NormalizedSample{
+			StacktraceID: id,
+			Value:        values[i],
+		})
+	}
+
+	return np, nil
+}
@@ -26,6 +26,7 @@ import (
 
 	pprofproto "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type Table interface {
@@ -100,12 +101,18 @@ func (ing Ingester) Ingest(ctx context.Context, ls labels.Labels, p *pprofproto.
 	return nil
 }
 
+// IngestProfile ingests the profile for the tenant of the context.
 func (ing Ingester) IngestProfile(ctx context.Context, ls labels.Labels, p *profile.NormalizedProfile) error {
-	buffer, err := NormalizedProfileToParquetBuffer(ing.schema, ls, p)
+	buffer, err := NormalizedProfileToParquetBuffer(ing.schema, tenant.FromContext(ctx), ls, p)
 	if err != nil {
 		return fmt.Errorf("failed to convert samples to buffer: %w", err)
 	}
 
+	return ing.IngestBuffer(ctx, buffer)
+}
+
+// IngestBuffer sorts the buffer and inserts it into the table.
+func (ing Ingester) IngestBuffer(ctx context.Context, buffer *dynparquet.Buffer) error {
 	buffer.Sort()
 
 	// This is necessary because sorting a buffer makes concurrent reading not
@@ -113,7 +120,7 @@ func (ing Ingester) IngestProfile(ctx context.Context, ls labels.Labels, p *prof
 	// executes the cyclic sort once and makes the resulting buffer safe for
 	// concurrent reading as it no longer has to perform the cyclic sorting at
 	// read time. This should probably be improved in the parquet library.
-	buffer, err = buffer.Clone()
+	buffer, err := buffer.Clone()
 	if err != nil {
 		return err
 	}
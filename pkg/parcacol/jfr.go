@@ -0,0 +1,115 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"time"
+
+	"github.com/parca-dev/parca/pkg/jfr"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// NormalizeJFR returns a profile for each kind of sample in the recording:
+// execution samples as jfr_cpu:samples:count:cpu:nanoseconds, allocation
+// samples as jfr_alloc:alloc_space:bytes:space:bytes and lock contention
+// events as jfr_lock:delay:nanoseconds:contentions:count. Kinds the recording
+// has no samples of are skipped, and so are samples without a stack trace.
+// Samples of the same stack trace are merged.
+func (n *Normalizer) NormalizeJFR(ctx context.Context, rec *jfr.Recording) ([]*profile.NormalizedProfile, error) {
+	kinds := []struct {
+		meta    profile.Meta
+		samples []jfr.Sample
+	}{{
+		meta: profile.Meta{
+			Name:       "jfr_cpu",
+			SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+			PeriodType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+			Period:     rec.CPUPeriod,
+		},
+		samples: rec.ExecutionSamples,
+	}, {
+		meta: profile.Meta{
+			Name:       "jfr_alloc",
+			SampleType: profile.ValueType{Type: "alloc_space", Unit: "bytes"},
+			PeriodType: profile.ValueType{Type: "space", Unit: "bytes"},
+		},
+		samples: rec.AllocationSamples,
+	}, {
+		meta: profile.Meta{
+			Name:       "jfr_lock",
+			SampleType: profile.ValueType{Type: "delay", Unit: "nanoseconds"},
+			PeriodType: profile.ValueType{Type: "contentions", Unit: "count"},
+		},
+		samples: rec.LockSamples,
+	}}
+
+	// The stacktraces of all kinds are created at once, as they share most
+	// of their frames.
+	stackIndex := map[int]int{}
+	stacks := [][]stackFrame{}
+	for _, k := range kinds {
+		for _, s := range k.samples {
+			if _, ok := stackIndex[s.Stack]; ok || s.Stack < 0 || s.Stack >= len(rec.Stacks) || len(rec.Stacks[s.Stack]) == 0 {
+				continue
+			}
+
+			frames := make([]stackFrame, 0, len(rec.Stacks[s.Stack]))
+			for _, f := range rec.Stacks[s.Stack] {
+				frames = append(frames, stackFrame{function: f.Function, line: f.Line})
+			}
+			stackIndex[s.Stack] = len(stacks)
+			stacks = append(stacks, frames)
+		}
+	}
+	if len(stacks) == 0 {
+		return nil, nil
+	}
+
+	ids, err := n.getOrCreateStacktraces(ctx, stacks)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*profile.NormalizedProfile, 0, len(kinds))
+	for _, k := range kinds {
+		k.meta.Timestamp = rec.Start / int64(time.Millisecond)
+		k.meta.Duration = rec.Duration
+		np := &profile.NormalizedProfile{Meta: k.meta}
+
+		// Stacks of different chunks may be the same stacktrace.
+		sampleIndex := map[string]int{}
+		for _, s := range k.samples {
+			i, ok := stackIndex[s.Stack]
+			if !ok {
+				continue
+			}
+			if j, ok := sampleIndex[ids[i]]; ok {
+				np.Samples[j].Value += s.Value
+				continue
+			}
+			sampleIndex[ids[i]] = len(np.Samples)
+			np.Samples = append(np.Samples, &profile.NormalizedSample{
+				StacktraceID: ids[i],
+				Value:        s.Value,
+			})
+		}
+
+		if len(np.Samples) > 0 {
+			profiles = append(profiles, np)
+		}
+	}
+
+	return profiles, nil
+}
@@ -253,3 +253,76 @@ func (n *Normalizer) NormalizeStacktraces(ctx context.Context, samples []*pprofp
 
 	return res.Stacktraces, nil
 }
+
+// stackFrame is a frame only identified by the name of its function and its
+// line, like the frames of folded stacks and JFR recordings.
+type stackFrame struct {
+	function string
+	line     int64
+}
+
+// getOrCreateStacktraces creates the functions, locations and stacktraces of
+// the stacks, whose frames are leaf first, and returns the IDs of their
+// stacktraces in the same order. Locations have no mapping, so they are
+// identified by their function and line.
+func (n *Normalizer) getOrCreateStacktraces(ctx context.Context, stacks [][]stackFrame) ([]string, error) {
+	functionIndex := map[string]int{}
+	functions := []*pb.Function{}
+	locationIndex := map[stackFrame]int{}
+	frames := []stackFrame{}
+	for _, stack := range stacks {
+		for _, f := range stack {
+			if _, ok := locationIndex[f]; ok {
+				continue
+			}
+			locationIndex[f] = len(frames)
+			frames = append(frames, f)
+
+			if _, ok := functionIndex[f.function]; !ok {
+				functionIndex[f.function] = len(functions)
+				functions = append(functions, &pb.Function{Name: f.function})
+			}
+		}
+	}
+
+	fres, err := n.metastore.GetOrCreateFunctions(ctx, &pb.GetOrCreateFunctionsRequest{Functions: functions})
+	if err != nil {
+		return nil, fmt.Errorf("get or create functions: %w", err)
+	}
+
+	locations := make([]*pb.Location, 0, len(frames))
+	for _, f := range frames {
+		locations = append(locations, &pb.Location{
+			Lines: &pb.LocationLines{Entries: []*pb.Line{{
+				FunctionId: fres.Functions[functionIndex[f.function]].Id,
+				Line:       f.line,
+			}}},
+		})
+	}
+
+	lres, err := n.metastore.GetOrCreateLocations(ctx, &pb.GetOrCreateLocationsRequest{Locations: locations})
+	if err != nil {
+		return nil, fmt.Errorf("get or create locations: %w", err)
+	}
+
+	stacktraces := make([]*pb.Stacktrace, 0, len(stacks))
+	for _, stack := range stacks {
+		locationIds := make([]string, 0, len(stack))
+		for _, f := range stack {
+			locationIds = append(locationIds, lres.Locations[locationIndex[f]].Id)
+		}
+		stacktraces = append(stacktraces, &pb.Stacktrace{LocationIds: locationIds})
+	}
+
+	sres, err := n.metastore.GetOrCreateStacktraces(ctx, &pb.GetOrCreateStacktracesRequest{Stacktraces: stacktraces})
+	if err != nil {
+		return nil, fmt.Errorf("get or create stacktraces: %w", err)
+	}
+
+	ids := make([]string, 0, len(sres.Stacktraces))
+	for _, st := range sres.Stacktraces {
+		ids = append(ids, st.Id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,337 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"fmt"
+	"strconv"
+
+	otlpcommonpb "go.opentelemetry.io/proto/otlp/common/v1"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	otlpprofilespb "github.com/parca-dev/parca/gen/proto/go/parca/otlp/profiles/v1development"
+)
+
+// buildIDAttributes are the OTLP mapping attributes holding the build ID of
+// the mapped executable, in order of preference.
+var buildIDAttributes = []string{
+	"process.executable.build_id.gnu",
+	"process.executable.build_id.go",
+	"process.executable.build_id.htlhash",
+}
+
+// OTLPAttributeValue returns the value of an OTLP attribute as a string.
+// Array, key-value list and bytes values are not supported.
+func OTLPAttributeValue(v *otlpcommonpb.AnyValue) (string, bool) {
+	switch v := v.GetValue().(type) {
+	case *otlpcommonpb.AnyValue_StringValue:
+		return v.StringValue, true
+	case *otlpcommonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue), true
+	case *otlpcommonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10), true
+	case *otlpcommonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// OTLPAttributes returns the attributes of the dictionary with the given
+// indices.
+func OTLPAttributes(d *otlpprofilespb.ProfilesDictionary, indices []int32) ([]*otlpcommonpb.KeyValue, error) {
+	attrs := make([]*otlpcommonpb.KeyValue, 0, len(indices))
+	for _, i := range indices {
+		if i < 0 || int(i) >= len(d.AttributeTable) {
+			return nil, fmt.Errorf("attribute index %d out of range", i)
+		}
+		attrs = append(attrs, d.AttributeTable[i])
+	}
+
+	return attrs, nil
+}
+
+// otlpConverter converts an OTLP profile to a pprof profile. Only the
+// mappings, locations and functions of the dictionary referenced by the
+// samples of the profile are converted.
+type otlpConverter struct {
+	d *otlpprofilespb.ProfilesDictionary
+	p *pprofpb.Profile
+
+	strings   map[string]int64
+	mappings  map[int32]uint64
+	functions map[int32]uint64
+	locations map[int32]uint64
+}
+
+// OTLPProfileToPprof converts the OTLP profile, whose mappings, locations,
+// functions, strings and attributes are stored in the dictionary, to a pprof
+// profile. String and integer sample attributes are converted to labels and
+// numeric labels respectively.
+func OTLPProfileToPprof(d *otlpprofilespb.ProfilesDictionary, p *otlpprofilespb.Profile) (*pprofpb.Profile, error) {
+	if d == nil {
+		d = &otlpprofilespb.ProfilesDictionary{}
+	}
+
+	c := &otlpConverter{
+		d: d,
+		p: &pprofpb.Profile{
+			StringTable:   []string{""},
+			TimeNanos:     p.TimeNanos,
+			DurationNanos: p.DurationNanos,
+			Period:        p.Period,
+		},
+		strings:   map[string]int64{"": 0},
+		mappings:  map[int32]uint64{},
+		functions: map[int32]uint64{},
+		locations: map[int32]uint64{},
+	}
+
+	var err error
+	for _, st := range p.SampleType {
+		vt, err := c.valueType(st)
+		if err != nil {
+			return nil, err
+		}
+		c.p.SampleType = append(c.p.SampleType, vt)
+	}
+	if p.PeriodType != nil {
+		c.p.PeriodType, err = c.valueType(p.PeriodType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, i := range p.CommentStrindices {
+		comment, err := c.string(i)
+		if err != nil {
+			return nil, err
+		}
+		c.p.Comment = append(c.p.Comment, comment)
+	}
+
+	for i, s := range p.Sample {
+		start, length := int(s.LocationsStartIndex), int(s.LocationsLength)
+		if start < 0 || length < 0 || start+length > len(p.LocationIndices) {
+			return nil, fmt.Errorf("sample %d locations [%d, %d) out of range", i, start, start+length)
+		}
+
+		sample := &pprofpb.Sample{
+			LocationId: make([]uint64, 0, length),
+			Value:      s.Value,
+		}
+		// Samples may only record the timestamps they were taken at, which
+		// then count as a single value each.
+		if len(sample.Value) == 0 && len(s.TimestampsUnixNano) > 0 && len(p.SampleType) == 1 {
+			sample.Value = []int64{int64(len(s.TimestampsUnixNano))}
+		}
+
+		for _, index := range p.LocationIndices[start : start+length] {
+			id, err := c.location(index)
+			if err != nil {
+				return nil, err
+			}
+			sample.LocationId = append(sample.LocationId, id)
+		}
+
+		attrs, err := OTLPAttributes(d, s.AttributeIndices)
+		if err != nil {
+			return nil, err
+		}
+		for _, attr := range attrs {
+			label := &pprofpb.Label{Key: c.addString(attr.Key)}
+			if v, ok := attr.Value.GetValue().(*otlpcommonpb.AnyValue_IntValue); ok {
+				label.Num = v.IntValue
+				label.NumUnit = c.addString(c.attributeUnit(attr.Key))
+			} else if v, ok := OTLPAttributeValue(attr.Value); ok {
+				label.Str = c.addString(v)
+			} else {
+				continue
+			}
+			sample.Label = append(sample.Label, label)
+		}
+
+		c.p.Sample = append(c.p.Sample, sample)
+	}
+
+	return c.p, nil
+}
+
+func (c *otlpConverter) string(i int32) (int64, error) {
+	if i < 0 || int(i) >= len(c.d.StringTable) {
+		return 0, fmt.Errorf("string index %d out of range", i)
+	}
+
+	return c.addString(c.d.StringTable[i]), nil
+}
+
+func (c *otlpConverter) addString(s string) int64 {
+	if i, ok := c.strings[s]; ok {
+		return i
+	}
+
+	i := int64(len(c.p.StringTable))
+	c.p.StringTable = append(c.p.StringTable, s)
+	c.strings[s] = i
+	return i
+}
+
+func (c *otlpConverter) valueType(vt *otlpprofilespb.ValueType) (*pprofpb.ValueType, error) {
+	typ, err := c.string(vt.TypeStrindex)
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := c.string(vt.UnitStrindex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pprofpb.ValueType{Type: typ, Unit: unit}, nil
+}
+
+// attributeUnit returns the unit of the attribute with the given key, or the
+// empty string if the dictionary does not define one.
+func (c *otlpConverter) attributeUnit(key string) string {
+	for _, u := range c.d.AttributeUnits {
+		k, err := c.string(u.AttributeKeyStrindex)
+		if err != nil || c.p.StringTable[k] != key {
+			continue
+		}
+		if unit, err := c.string(u.UnitStrindex); err == nil {
+			return c.p.StringTable[unit]
+		}
+	}
+
+	return ""
+}
+
+func (c *otlpConverter) location(i int32) (uint64, error) {
+	if id, ok := c.locations[i]; ok {
+		return id, nil
+	}
+	if i < 0 || int(i) >= len(c.d.LocationTable) {
+		return 0, fmt.Errorf("location index %d out of range", i)
+	}
+	l := c.d.LocationTable[i]
+
+	location := &pprofpb.Location{
+		Address:  l.Address,
+		IsFolded: l.IsFolded,
+		Line:     make([]*pprofpb.Line, 0, len(l.Line)),
+	}
+	if l.MappingIndex != nil {
+		id, err := c.mapping(*l.MappingIndex)
+		if err != nil {
+			return 0, err
+		}
+		location.MappingId = id
+	}
+	for _, line := range l.Line {
+		id, err := c.function(line.FunctionIndex)
+		if err != nil {
+			return 0, err
+		}
+		location.Line = append(location.Line, &pprofpb.Line{
+			FunctionId: id,
+			Line:       line.Line,
+		})
+	}
+
+	location.Id = uint64(len(c.p.Location) + 1)
+	c.p.Location = append(c.p.Location, location)
+	c.locations[i] = location.Id
+	return location.Id, nil
+}
+
+func (c *otlpConverter) mapping(i int32) (uint64, error) {
+	if id, ok := c.mappings[i]; ok {
+		return id, nil
+	}
+	if i < 0 || int(i) >= len(c.d.MappingTable) {
+		return 0, fmt.Errorf("mapping index %d out of range", i)
+	}
+	m := c.d.MappingTable[i]
+
+	filename, err := c.string(m.FilenameStrindex)
+	if err != nil {
+		return 0, err
+	}
+
+	attrs, err := OTLPAttributes(c.d, m.AttributeIndices)
+	if err != nil {
+		return 0, err
+	}
+	buildID := ""
+	for _, key := range buildIDAttributes {
+		for _, attr := range attrs {
+			if attr.Key == key {
+				buildID, _ = OTLPAttributeValue(attr.Value)
+				break
+			}
+		}
+		if buildID != "" {
+			break
+		}
+	}
+
+	mapping := &pprofpb.Mapping{
+		Id:              uint64(len(c.p.Mapping) + 1),
+		MemoryStart:     m.MemoryStart,
+		MemoryLimit:     m.MemoryLimit,
+		FileOffset:      m.FileOffset,
+		Filename:        filename,
+		BuildId:         c.addString(buildID),
+		HasFunctions:    m.HasFunctions,
+		HasFilenames:    m.HasFilenames,
+		HasLineNumbers:  m.HasLineNumbers,
+		HasInlineFrames: m.HasInlineFrames,
+	}
+	c.p.Mapping = append(c.p.Mapping, mapping)
+	c.mappings[i] = mapping.Id
+	return mapping.Id, nil
+}
+
+func (c *otlpConverter) function(i int32) (uint64, error) {
+	if id, ok := c.functions[i]; ok {
+		return id, nil
+	}
+	if i < 0 || int(i) >= len(c.d.FunctionTable) {
+		return 0, fmt.Errorf("function index %d out of range", i)
+	}
+	f := c.d.FunctionTable[i]
+
+	name, err := c.string(f.NameStrindex)
+	if err != nil {
+		return 0, err
+	}
+	systemName, err := c.string(f.SystemNameStrindex)
+	if err != nil {
+		return 0, err
+	}
+	filename, err := c.string(f.FilenameStrindex)
+	if err != nil {
+		return 0, err
+	}
+
+	function := &pprofpb.Function{
+		Id:         uint64(len(c.p.Function) + 1),
+		Name:       name,
+		SystemName: systemName,
+		Filename:   filename,
+		StartLine:  f.StartLine,
+	}
+	c.p.Function = append(c.p.Function, function)
+	c.functions[i] = function.Id
+	return function.Id, nil
+}
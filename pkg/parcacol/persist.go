@@ -0,0 +1,330 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/polarsignals/frostdb/pqarrow"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/segmentio/parquet-go"
+	"github.com/thanos-io/objstore"
+)
+
+// blockFileName is the name frostdb persists the data of a block as.
+const blockFileName = "data.parquet"
+
+// blockListTTL is how long the listed blocks of a table are reused for, unless
+// the active block of the table was rotated since. Blocks deleted or replaced by
+// the retention and the downsampler are noticed once it expires.
+const blockListTTL = time.Minute
+
+// BlockTableProvider provides the tables of a database to the query engine.
+// The columnstore reads persisted blocks lazily when a table is scanned, but
+// only derives the schema of a table from its active block, so columns only
+// found in persisted blocks, like the labels of series not written since a
+// restart, would not be queryable. The provider adds the dynamic columns of
+// persisted blocks to the schema, reading only the footers of the blocks and
+// never rewriting them.
+type BlockTableProvider struct {
+	logger log.Logger
+	db     *frostdb.DB
+	bucket objstore.Bucket
+
+	mtx sync.Mutex
+	// lists are the blocks of each table listed last.
+	lists map[string]blockList
+	// blocks are the dynamic columns by the path of each block read so far.
+	// Blocks are immutable, so they only need to be read once.
+	blocks map[string]map[string][]string
+}
+
+// blockList are the blocks of a table, and the active block of the table and
+// the time when they were listed.
+type blockList struct {
+	blocks []string
+	active *frostdb.TableBlock
+	listed time.Time
+}
+
+// NewBlockTableProvider returns a provider of the tables of the database,
+// whose blocks are persisted to the bucket. The bucket may be nil if the
+// database does not persist blocks.
+func NewBlockTableProvider(logger log.Logger, db *frostdb.DB, bucket objstore.Bucket) *BlockTableProvider {
+	return &BlockTableProvider{
+		logger: logger,
+		db:     db,
+		bucket: bucket,
+		lists:  map[string]blockList{},
+		blocks: map[string]map[string][]string{},
+	}
+}
+
+// GetTable returns the table of the given name.
+func (p *BlockTableProvider) GetTable(name string) logicalplan.TableReader {
+	table := p.db.TableProvider().GetTable(name)
+	t, ok := table.(*frostdb.Table)
+	if !ok || t == nil || p.bucket == nil {
+		return table
+	}
+
+	return &blockTable{
+		TableReader: table,
+		provider:    p,
+		table:       t,
+		name:        name,
+	}
+}
+
+// dynamicColumns returns the dynamic columns of all blocks of the table
+// persisted to the bucket. The footers of blocks not read before are read
+// without holding the lock, so that queries are not blocked by them.
+func (p *BlockTableProvider) dynamicColumns(ctx context.Context, table *frostdb.Table, name string) (map[string][]string, error) {
+	blocks, err := p.listBlocks(ctx, table, name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	unread := []string{}
+	for _, block := range blocks {
+		if _, ok := p.blocks[block]; !ok {
+			unread = append(unread, block)
+		}
+	}
+	p.mtx.Unlock()
+
+	read := make(map[string]map[string][]string, len(unread))
+	for _, block := range unread {
+		dyn, err := readDynamicColumns(ctx, p.bucket, block)
+		if err != nil {
+			// The block may still be being uploaded, it is read again by
+			// the next query.
+			level.Debug(p.logger).Log("msg", "failed to read dynamic columns of block", "block", block, "err", err)
+			continue
+		}
+		read[block] = dyn
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for block, dyn := range read {
+		p.blocks[block] = dyn
+	}
+
+	columns := map[string]map[string]struct{}{}
+	for _, block := range blocks {
+		for name, values := range p.blocks[block] {
+			if _, ok := columns[name]; !ok {
+				columns[name] = map[string]struct{}{}
+			}
+			for _, v := range values {
+				columns[name][v] = struct{}{}
+			}
+		}
+	}
+
+	dynamicColumns := make(map[string][]string, len(columns))
+	for name, values := range columns {
+		dynamicColumns[name] = make([]string, 0, len(values))
+		for v := range values {
+			dynamicColumns[name] = append(dynamicColumns[name], v)
+		}
+		sort.Strings(dynamicColumns[name])
+	}
+
+	return dynamicColumns, nil
+}
+
+// listBlocks returns the paths of the blocks of the table. They are listed
+// again once the active block of the table was rotated, which persists it, or
+// after blockListTTL.
+func (p *BlockTableProvider) listBlocks(ctx context.Context, table *frostdb.Table, name string) ([]string, error) {
+	active := table.ActiveBlock()
+
+	p.mtx.Lock()
+	list, ok := p.lists[name]
+	p.mtx.Unlock()
+	if ok && list.active == active && time.Since(list.listed) < blockListTTL {
+		return list.blocks, nil
+	}
+
+	listed := time.Now()
+	blocks := []string{}
+	err := p.bucket.Iter(ctx, name, func(dir string) error {
+		blocks = append(blocks, path.Join(dir, blockFileName))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list blocks: %w", err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.lists[name] = blockList{
+		blocks: blocks,
+		active: active,
+		listed: listed,
+	}
+
+	// Forget the blocks that were deleted, by retention for example.
+	seen := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		seen[block] = struct{}{}
+	}
+	for block := range p.blocks {
+		if _, ok := seen[block]; !ok && strings.HasPrefix(block, name+"/") {
+			delete(p.blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+// readDynamicColumns reads the dynamic columns of a block from its footer.
+func readDynamicColumns(ctx context.Context, bucket objstore.Bucket, name string) (map[string][]string, error) {
+	attrs, err := bucket.Attributes(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := parquet.OpenFile(
+		bucketReaderAt{ctx: ctx, bucket: bucket, name: name},
+		attrs.Size,
+		parquet.SkipPageIndex(true),
+		parquet.SkipBloomFilters(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := dynparquet.NewSerializedBuffer(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.DynamicColumns(), nil
+}
+
+// bucketReaderAt reads ranges of an object of a bucket, so that only the
+// parts of a block that are needed are fetched.
+type bucketReaderAt struct {
+	ctx    context.Context
+	bucket objstore.Bucket
+	name   string
+}
+
+func (r bucketReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	rc, err := r.bucket.GetRange(r.ctx, r.name, off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	return io.ReadFull(rc, p)
+}
+
+// blockTable is a table whose schema includes the dynamic columns of its
+// persisted blocks.
+type blockTable struct {
+	logicalplan.TableReader
+	provider *BlockTableProvider
+	table    *frostdb.Table
+	name     string
+}
+
+func (t *blockTable) ArrowSchema(
+	ctx context.Context,
+	tx uint64,
+	pool memory.Allocator,
+	projections []logicalplan.ColumnMatcher,
+	filterExpr logicalplan.Expr,
+	distinctColumns []logicalplan.ColumnMatcher,
+) (*arrow.Schema, error) {
+	schema, err := t.TableReader.ArrowSchema(ctx, tx, pool, projections, filterExpr, distinctColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicColumns, err := t.provider.dynamicColumns(ctx, t.table, t.name)
+	if err != nil {
+		return nil, err
+	}
+	if len(dynamicColumns) == 0 {
+		return schema, nil
+	}
+
+	// An empty buffer has the schema of blocks with all these columns.
+	buf, err := t.Schema().NewBuffer(dynamicColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	persisted, err := pqarrow.ParquetRowGroupToArrowSchema(ctx, buf, projections, filterExpr, distinctColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeArrowSchemas(schema, persisted), nil
+}
+
+// mergeArrowSchemas returns the union of the fields of the schemas, sorted by
+// their names like the schemas of the columnstore.
+func mergeArrowSchemas(a, b *arrow.Schema) *arrow.Schema {
+	names := make([]string, 0, len(a.Fields())+len(b.Fields()))
+	fields := make(map[string]arrow.Field, len(a.Fields())+len(b.Fields()))
+	for _, s := range []*arrow.Schema{a, b} {
+		for _, f := range s.Fields() {
+			if _, ok := fields[f.Name]; !ok {
+				names = append(names, f.Name)
+				fields[f.Name] = f
+			}
+		}
+	}
+	sort.Strings(names)
+
+	merged := make([]arrow.Field, 0, len(names))
+	for _, name := range names {
+		merged = append(merged, fields[name])
+	}
+
+	return arrow.NewSchema(merged, nil)
+}
+
+// PersistActiveBlock writes the active block of the table to its bucket.
+// Blocks are otherwise only persisted once they are rotated, so this needs to
+// happen on shutdown for the active block to be available after a restart.
+func PersistActiveBlock(table *frostdb.Table) error {
+	block := table.ActiveBlock()
+	if block.Size() == 0 {
+		return nil
+	}
+
+	return block.Persist()
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/query"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func newTestTable(t *testing.T, bucket objstore.Bucket) (*frostdb.DB, *frostdb.Table) {
+	t.Helper()
+
+	col := frostdb.New(prometheus.NewRegistry(), 8196, 64*1024*1024).WithStorageBucket(bucket)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table("stacktraces", frostdb.NewTableConfig(Schema()), log.NewNopLogger())
+	require.NoError(t, err)
+
+	return colDB, table
+}
+
+func bucketObjects(t *testing.T, bucket *objstore.InMemBucket) []string {
+	t.Helper()
+
+	names := []string{}
+	for name := range bucket.Objects() {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// iterCountingBucket counts the listings of the bucket.
+type iterCountingBucket struct {
+	objstore.Bucket
+	iters int
+}
+
+func (b *iterCountingBucket) Iter(ctx context.Context, dir string, f func(string) error, options ...objstore.IterOption) error {
+	b.iters++
+	return b.Bucket.Iter(ctx, dir, f, options...)
+}
+
+func TestBlockTableProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	bucket := objstore.NewInMemBucket()
+
+	_, table := newTestTable(t, bucket)
+	buf, err := NormalizedProfileToParquetBuffer(
+		Schema(),
+		"",
+		labels.Labels{{Name: "job", Value: "default"}},
+		&profile.NormalizedProfile{
+			Meta: profile.Meta{
+				Name:       "memory",
+				SampleType: profile.ValueType{Type: "alloc_objects", Unit: "count"},
+				PeriodType: profile.ValueType{Type: "space", Unit: "bytes"},
+				Timestamp:  1,
+			},
+			Samples: []*profile.NormalizedSample{{StacktraceID: "stacktrace", Value: 3}},
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, NewIngester(logger, nil, table).IngestBuffer(ctx, buf))
+	require.NoError(t, PersistActiveBlock(table))
+
+	objects := bucketObjects(t, bucket)
+	require.Len(t, objects, 1)
+
+	// Restart with the same bucket. Blocks created in the same millisecond
+	// as the active block are not read.
+	time.Sleep(2 * time.Millisecond)
+	colDB, _ := newTestTable(t, bucket)
+
+	scan := func(provider logicalplan.TableProvider) (fields []string, jobs []string) {
+		err := query.NewEngine(memory.DefaultAllocator, provider).
+			ScanTable("stacktraces").
+			Project(logicalplan.DynCol(ColumnLabels)).
+			Execute(ctx, func(ar arrow.Record) error {
+				for i, f := range ar.Schema().Fields() {
+					fields = append(fields, f.Name)
+					col := ar.Column(i).(*array.Binary)
+					for j := 0; j < col.Len(); j++ {
+						jobs = append(jobs, string(col.Value(j)))
+					}
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		return fields, jobs
+	}
+
+	// The columnstore only knows the columns of the active block.
+	fields, _ := scan(colDB.TableProvider())
+	require.Empty(t, fields)
+
+	prefixed := &iterCountingBucket{Bucket: frostdb.NewPrefixedBucket(bucket, colDB.StorePath())}
+	provider := NewBlockTableProvider(logger, colDB, prefixed)
+	fields, jobs := scan(provider)
+	require.Equal(t, []string{"labels.job"}, fields)
+	require.Equal(t, []string{"default"}, jobs)
+
+	// The blocks are not listed again until the active block is rotated.
+	fields, _ = scan(provider)
+	require.Equal(t, []string{"labels.job"}, fields)
+	require.Equal(t, 1, prefixed.iters)
+
+	// The persisted block is read in place rather than rewritten.
+	require.ElementsMatch(t, objects, bucketObjects(t, bucket))
+}
@@ -0,0 +1,235 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/polarsignals/frostdb/dynparquet"
+	"github.com/prometheus/common/model"
+	"github.com/segmentio/parquet-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+)
+
+// recordColumn is a column of an Arrow record of samples.
+type recordColumn struct {
+	arr arrow.Array
+}
+
+// value returns the value of the row as a Parquet value, strings being stored
+// as either string or binary arrays.
+func (c recordColumn) value(i int) parquet.Value {
+	switch arr := c.arr.(type) {
+	case *array.String:
+		return parquet.ValueOf(arr.Value(i))
+	case *array.Binary:
+		return parquet.ValueOf(arr.ValueString(i))
+	case *array.Int64:
+		return parquet.ValueOf(arr.Value(i))
+	default:
+		panic(fmt.Errorf("unsupported column type %T", c.arr))
+	}
+}
+
+// dynamicRecordColumns are the columns of a dynamic column of an Arrow record,
+// sorted by their name.
+type dynamicRecordColumns struct {
+	names   []string
+	columns []recordColumn
+}
+
+func (d *dynamicRecordColumns) Len() int           { return len(d.names) }
+func (d *dynamicRecordColumns) Less(i, j int) bool { return d.names[i] < d.names[j] }
+func (d *dynamicRecordColumns) Swap(i, j int) {
+	d.names[i], d.names[j] = d.names[j], d.names[i]
+	d.columns[i], d.columns[j] = d.columns[j], d.columns[i]
+}
+
+// ArrowRecordToParquetBuffer converts an Arrow record of already normalized
+// samples to a Parquet buffer of the tenant. The record must have a column
+// for every static column of the schema except for the tenant, dynamic
+// columns are named <column>.<name>, e.g. labels.job. The stacktraces of the
+// samples must be known to the metastore of the tenant of the context. Errors
+// reading them from the metastore are returned as gRPC status errors, all
+// other errors mean the record is invalid.
+func ArrowRecordToParquetBuffer(
+	ctx context.Context,
+	m pb.MetastoreServiceClient,
+	schema *dynparquet.Schema,
+	tenant string,
+	ar arrow.Record,
+) (*dynparquet.Buffer, error) {
+	static := map[string]recordColumn{}
+	dynamic := map[string]*dynamicRecordColumns{
+		ColumnLabels:         {},
+		ColumnPprofLabels:    {},
+		ColumnPprofNumLabels: {},
+	}
+
+	seen := map[string]struct{}{}
+	for i, field := range ar.Schema().Fields() {
+		if _, ok := seen[field.Name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", field.Name)
+		}
+		seen[field.Name] = struct{}{}
+		col := recordColumn{arr: ar.Column(i)}
+
+		if column, name, ok := strings.Cut(field.Name, "."); ok {
+			d, ok := dynamic[column]
+			if !ok {
+				return nil, fmt.Errorf("unknown dynamic column %q", field.Name)
+			}
+			if column == ColumnLabels && !model.LabelName(name).IsValid() {
+				return nil, fmt.Errorf("invalid label name: %v", name)
+			}
+			if err := checkColumnType(field, column == ColumnPprofNumLabels); err != nil {
+				return nil, err
+			}
+			d.names = append(d.names, name)
+			d.columns = append(d.columns, col)
+			continue
+		}
+
+		switch field.Name {
+		case ColumnTenant:
+			return nil, fmt.Errorf("tenant column must not be set, it is taken from the request")
+		case ColumnDuration, ColumnPeriod, ColumnTimestamp, ColumnValue:
+			if err := checkColumnType(field, true); err != nil {
+				return nil, err
+			}
+		case ColumnName, ColumnPeriodType, ColumnPeriodUnit, ColumnSampleType, ColumnSampleUnit, ColumnStacktrace:
+			if err := checkColumnType(field, false); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown column %q", field.Name)
+		}
+		if col.arr.NullN() > 0 {
+			return nil, fmt.Errorf("column %q must not contain nulls", field.Name)
+		}
+		static[field.Name] = col
+	}
+
+	for _, column := range schema.Columns() {
+		if _, ok := dynamic[column.Name]; ok || column.Name == ColumnTenant {
+			continue
+		}
+		if _, ok := static[column.Name]; !ok {
+			return nil, fmt.Errorf("missing column %q", column.Name)
+		}
+	}
+
+	if err := checkStacktraces(ctx, m, static[ColumnStacktrace]); err != nil {
+		return nil, err
+	}
+
+	dynamicColumns := map[string][]string{}
+	for column, d := range dynamic {
+		sort.Sort(d)
+		dynamicColumns[column] = d.names
+	}
+
+	pb, err := schema.NewBuffer(dynamicColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := int(ar.NumRows())
+	var r parquet.Row
+	for i := 0; i < rows; i++ {
+		r = r[:0]
+		columnIndex := 0
+		for _, column := range schema.Columns() {
+			switch column.Name {
+			case ColumnTenant:
+				r = append(r, parquet.ValueOf(tenant).Level(0, 0, columnIndex))
+				columnIndex++
+			case ColumnLabels, ColumnPprofLabels, ColumnPprofNumLabels:
+				for _, col := range dynamic[column.Name].columns {
+					if col.arr.IsNull(i) {
+						r = append(r, parquet.ValueOf(nil).Level(0, 0, columnIndex))
+					} else {
+						r = append(r, col.value(i).Level(0, 1, columnIndex))
+					}
+					columnIndex++
+				}
+			default:
+				r = append(r, static[column.Name].value(i).Level(0, 0, columnIndex))
+				columnIndex++
+			}
+		}
+
+		if _, err := pb.WriteRows([]parquet.Row{r}); err != nil {
+			return nil, err
+		}
+	}
+
+	return pb, nil
+}
+
+// checkStacktraces checks that the distinct stacktraces of the column are
+// known to the metastore, so that samples of unknown stacktraces are not
+// ingested.
+func checkStacktraces(ctx context.Context, m pb.MetastoreServiceClient, col recordColumn) error {
+	ids := []string{}
+	seen := map[string]struct{}{}
+	for i := 0; i < col.arr.Len(); i++ {
+		id := string(col.value(i).ByteArray())
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := m.Stacktraces(ctx, &pb.StacktracesRequest{StacktraceIds: ids})
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("unknown stacktrace: %s", status.Convert(err).Message())
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "read stacktraces: %v", err)
+	}
+
+	return nil
+}
+
+// checkColumnType checks that the field is an int64 or a string column.
+func checkColumnType(field arrow.Field, int64Column bool) error {
+	switch field.Type.ID() {
+	case arrow.INT64:
+		if int64Column {
+			return nil
+		}
+	case arrow.STRING, arrow.BINARY:
+		if !int64Column {
+			return nil
+		}
+	}
+
+	if int64Column {
+		return fmt.Errorf("expected column %q to be int64, got %s", field.Name, field.Type)
+	}
+	return fmt.Errorf("expected column %q to be string or binary, got %s", field.Name, field.Type)
+}
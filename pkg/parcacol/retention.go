@@ -0,0 +1,265 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parcacol
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/memory"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/segmentio/parquet-go"
+	"github.com/thanos-io/objstore"
+
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// GarbageCollector removes all metadata of the tenant of the context no longer
+// referenced by the stacktraces returned by the given function.
+type GarbageCollector interface {
+	Tenants(ctx context.Context) ([]string, error)
+	GarbageCollect(ctx context.Context, liveStacktraces func(context.Context) (map[string]struct{}, error)) (int, error)
+}
+
+// Retention enforces a retention period on a table. Blocks persisted to the
+// bucket that only contain samples older than the retention period are
+// deleted, after which the metadata no longer referenced by any sample of the
+// table is garbage collected. Data is only dropped at the granularity of
+// persisted blocks, the active block is bounded by the active memory instead.
+type Retention struct {
+	logger    log.Logger
+	bucket    objstore.Bucket
+	tableName string
+	table     *frostdb.Table
+	gc        GarbageCollector
+	retention time.Duration
+	blocksMtx sync.Locker
+}
+
+// NewRetention returns a new Retention. The bucket may be nil if the table is
+// not persisted, in which case only the metadata is garbage collected. The
+// blocks lock is held while expired blocks are deleted, it is to be shared
+// with the downsampler of the table.
+func NewRetention(
+	logger log.Logger,
+	bucket objstore.Bucket,
+	tableName string,
+	table *frostdb.Table,
+	gc GarbageCollector,
+	retention time.Duration,
+	blocksMtx sync.Locker,
+) *Retention {
+	return &Retention{
+		logger:    logger,
+		bucket:    bucket,
+		tableName: tableName,
+		table:     table,
+		gc:        gc,
+		retention: retention,
+		blocksMtx: blocksMtx,
+	}
+}
+
+// Run enforces the retention every interval until the context is canceled.
+func (r *Retention) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.Enforce(ctx); err != nil {
+				level.Error(r.logger).Log("msg", "failed to enforce retention", "err", err)
+			}
+		}
+	}
+}
+
+// Enforce deletes all expired blocks and garbage collects the metadata that
+// is no longer referenced.
+func (r *Retention) Enforce(ctx context.Context) error {
+	if r.bucket != nil && r.retention > 0 {
+		cutoff := time.Now().Add(-r.retention)
+		n, err := r.deleteExpiredBlocks(ctx, cutoff)
+		if err != nil {
+			return fmt.Errorf("delete expired blocks: %w", err)
+		}
+		level.Debug(r.logger).Log("msg", "deleted expired blocks", "blocks", n, "cutoff", cutoff)
+	}
+
+	tenants, err := r.gc.Tenants(ctx)
+	if err != nil {
+		return fmt.Errorf("list tenants: %w", err)
+	}
+
+	for _, t := range tenants {
+		n, err := r.gc.GarbageCollect(tenant.NewContext(ctx, t), r.stacktraceIDs)
+		if err != nil {
+			return fmt.Errorf("garbage collect metastore of tenant %q: %w", t, err)
+		}
+		level.Debug(r.logger).Log("msg", "garbage collected metastore", "tenant", t, "deleted", n)
+	}
+
+	return nil
+}
+
+func (r *Retention) deleteExpiredBlocks(ctx context.Context, cutoff time.Time) (int, error) {
+	r.blocksMtx.Lock()
+	defer r.blocksMtx.Unlock()
+
+	blocks := []string{}
+	err := r.bucket.Iter(ctx, r.tableName, func(dir string) error {
+		blocks = append(blocks, path.Join(dir, blockFileName))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list blocks: %w", err)
+	}
+
+	deleted := 0
+	for _, block := range blocks {
+		maxTimestamp, err := r.blockMaxTimestamp(ctx, block)
+		if err != nil {
+			return deleted, fmt.Errorf("read block %q: %w", block, err)
+		}
+
+		if maxTimestamp >= timestamp.FromTime(cutoff) {
+			continue
+		}
+
+		if err := r.bucket.Delete(ctx, block); err != nil {
+			return deleted, fmt.Errorf("delete block %q: %w", block, err)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// blockMaxTimestamp returns the timestamp of the newest sample in the block.
+func (r *Retention) blockMaxTimestamp(ctx context.Context, name string) (int64, error) {
+	rc, err := r.bucket.Get(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	buf, err := io.ReadAll(rc)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return 0, err
+	}
+
+	return maxTimestamp(f), nil
+}
+
+// maxTimestamp returns the timestamp of the newest sample in the file, as
+// recorded in the column index of the timestamp column.
+func maxTimestamp(f *parquet.File) int64 {
+	max := int64(0)
+	for _, rg := range f.RowGroups() {
+		for i, field := range rg.Schema().Fields() {
+			if field.Name() != ColumnTimestamp {
+				continue
+			}
+
+			idx := rg.ColumnChunks()[i].ColumnIndex()
+			for page := 0; page < idx.NumPages(); page++ {
+				if v := idx.MaxValue(page); !v.IsNull() && v.Int64() > max {
+					max = v.Int64()
+				}
+			}
+		}
+	}
+
+	return max
+}
+
+// stacktraceIDs returns the IDs of all stacktraces referenced by the samples
+// of the tenant of the context. The table is iterated directly rather than
+// queried, as the query engine derives the schema from the active block only,
+// which is empty right after it was rotated. The filter only prunes row
+// groups, so the tenant of each row is checked as well.
+func (r *Retention) stacktraceIDs(ctx context.Context) (map[string]struct{}, error) {
+	t := tenant.FromContext(ctx)
+	ids := map[string]struct{}{}
+	err := r.table.View(func(tx uint64) error {
+		return r.table.Iterator(
+			ctx,
+			tx,
+			memory.DefaultAllocator,
+			nil,
+			[]logicalplan.ColumnMatcher{
+				logicalplan.Col(ColumnStacktrace).Matcher(),
+				logicalplan.Col(ColumnTenant).Matcher(),
+			},
+			logicalplan.Col(ColumnTenant).Eq(logicalplan.Literal(t)),
+			nil,
+			func(ar arrow.Record) error {
+				stacktraces, err := binaryColumn(ar, ColumnStacktrace)
+				if err != nil {
+					return err
+				}
+
+				tenants, err := binaryColumn(ar, ColumnTenant)
+				if err != nil {
+					return err
+				}
+
+				for i := 0; i < stacktraces.Len(); i++ {
+					if string(tenants.Value(i)) == t {
+						ids[string(stacktraces.Value(i))] = struct{}{}
+					}
+				}
+				return nil
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func binaryColumn(ar arrow.Record, name string) (*array.Binary, error) {
+	indices := ar.Schema().FieldIndices(name)
+	if len(indices) != 1 {
+		return nil, fmt.Errorf("expected exactly one %s column, got %d", name, len(indices))
+	}
+
+	col, ok := ar.Column(indices[0]).(*array.Binary)
+	if !ok {
+		return nil, fmt.Errorf("expected %s column to be binary, got %T", name, ar.Column(indices[0]))
+	}
+
+	return col, nil
+}
@@ -32,6 +32,7 @@ const (
 	ColumnSampleType     = "sample_type"
 	ColumnSampleUnit     = "sample_unit"
 	ColumnStacktrace     = "stacktrace"
+	ColumnTenant         = "tenant"
 	ColumnTimestamp      = "timestamp"
 	ColumnValue          = "value"
 )
@@ -84,6 +85,10 @@ func Schema() *dynparquet.Schema {
 				Name:          ColumnStacktrace,
 				StorageLayout: parquet.Encoded(parquet.String(), &parquet.RLEDictionary),
 				Dynamic:       false,
+			}, {
+				Name:          ColumnTenant,
+				StorageLayout: parquet.Encoded(parquet.String(), &parquet.RLEDictionary),
+				Dynamic:       false,
 			}, {
 				Name:          ColumnTimestamp,
 				StorageLayout: parquet.Int(64),
@@ -95,6 +100,7 @@ func Schema() *dynparquet.Schema {
 			},
 		},
 		[]dynparquet.SortingColumn{
+			dynparquet.Ascending(ColumnTenant),
 			dynparquet.Ascending(ColumnName),
 			dynparquet.Ascending(ColumnSampleType),
 			dynparquet.Ascending(ColumnSampleUnit),
@@ -30,7 +30,11 @@ type Location struct {
 	Address  uint64
 	IsFolded bool
 	Mapping  *pb.Mapping
-	Lines    []LocationLine
+	// Lines are the lines of the functions inlined into each other at the
+	// location. Like in pprof, they are ordered from the innermost to the
+	// outermost, so the first line is the leaf and the last one the function
+	// the others were inlined into.
+	Lines []LocationLine
 }
 
 type Label struct {
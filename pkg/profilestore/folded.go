@@ -0,0 +1,101 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+)
+
+// FoldedPath is the path folded stacks are pushed to over HTTP.
+const FoldedPath = "/ingest/folded"
+
+// FoldedHandler accepts folded stacks pushed over HTTP and writes them to the
+// profile store, so that they can be pushed by tools like curl, e.g.
+//
+//	curl --data-binary @stacks.folded \
+//	  'http://localhost:7070/ingest/folded?profile_type=process_cpu:samples:count:cpu:nanoseconds&label=job=api'
+type FoldedHandler struct {
+	logger log.Logger
+	store  profilestorepb.ProfileStoreServiceServer
+}
+
+func NewFoldedHandler(logger log.Logger, store profilestorepb.ProfileStoreServiceServer) *FoldedHandler {
+	return &FoldedHandler{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// ServeHTTP writes the folded stacks of the request body, optionally gzip
+// compressed. The profile type is set by the profile_type query parameter,
+// the labels of the series by label query parameters of the form
+// <name>=<value>, and the timestamp in milliseconds, duration and period by
+// the parameters of the same name. The tenant is selected by the tenant
+// header.
+func (h *FoldedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	lset, err := labelSetFromQuery(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &profilestorepb.WriteFoldedRequest{
+		Labels:      lset,
+		ProfileType: q.Get("profile_type"),
+	}
+	for _, param := range []struct {
+		name  string
+		value *int64
+	}{
+		{"timestamp", &req.Timestamp},
+		{"duration", &req.Duration},
+		{"period", &req.Period},
+	} {
+		if s := q.Get(param.name); s != "" {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid "+param.name+" "+strconv.Quote(s), http.StatusBadRequest)
+				return
+			}
+			*param.value = i
+		}
+	}
+
+	req.Folded, err = readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), readBodyStatusCode(err))
+		return
+	}
+
+	if _, err := h.store.WriteFolded(requestContext(r), req); err != nil {
+		level.Debug(h.logger).Log("msg", "failed to write folded stacks", "err", err)
+		http.Error(w, status.Convert(err).Message(), httpStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
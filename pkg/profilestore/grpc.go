@@ -15,12 +15,16 @@ package profilestore
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 // GRPCForwarder forward profiles via gRPC to another Parca instance
@@ -42,9 +46,80 @@ func NewGRPCForwarder(conn grpc.ClientConnInterface, logger log.Logger) *GRPCFor
 func (s *GRPCForwarder) WriteRaw(ctx context.Context, req *profilestorepb.WriteRawRequest) (*profilestorepb.WriteRawResponse, error) {
 	// TODO: Batch writes to only send a request every now and then.
 	// See https://github.com/parca-dev/parca-agent/blob/main/pkg/agent/write_client.go#L28
+	if t := tenant.FromContext(ctx); t != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.Header, t)
+	}
 	resp, err := s.client.WriteRaw(ctx, req)
 	if err != nil {
 		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
 	}
 	return resp, err
 }
+
+func (s *GRPCForwarder) WriteStream(stream profilestorepb.ProfileStoreService_WriteStreamServer) error {
+	ctx := stream.Context()
+	if t := tenant.FromContext(ctx); t != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.Header, t)
+	}
+
+	client, err := s.client.WriteStream(ctx)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := client.Send(req); err != nil {
+			// The error of the stream is returned by CloseAndRecv.
+			break
+		}
+	}
+
+	resp, err := client.CloseAndRecv()
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+func (s *GRPCForwarder) WriteArrow(ctx context.Context, req *profilestorepb.WriteArrowRequest) (*profilestorepb.WriteArrowResponse, error) {
+	if t := tenant.FromContext(ctx); t != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.Header, t)
+	}
+	resp, err := s.client.WriteArrow(ctx, req)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
+	}
+	return resp, err
+}
+
+func (s *GRPCForwarder) WriteFolded(ctx context.Context, req *profilestorepb.WriteFoldedRequest) (*profilestorepb.WriteFoldedResponse, error) {
+	if t := tenant.FromContext(ctx); t != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.Header, t)
+	}
+	resp, err := s.client.WriteFolded(ctx, req)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
+	}
+	return resp, err
+}
+
+func (s *GRPCForwarder) WriteJFR(ctx context.Context, req *profilestorepb.WriteJFRRequest) (*profilestorepb.WriteJFRResponse, error) {
+	if t := tenant.FromContext(ctx); t != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.Header, t)
+	}
+	resp, err := s.client.WriteJFR(ctx, req)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to forward profiles", "err", err)
+	}
+	return resp, err
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// labelSetFromQuery returns the labels of the label query parameters, of the
+// form <name>=<value>.
+func labelSetFromQuery(q url.Values) (*profilestorepb.LabelSet, error) {
+	lset := &profilestorepb.LabelSet{}
+	for _, l := range q["label"] {
+		name, value, ok := strings.Cut(l, "=")
+		if !ok {
+			return nil, fmt.Errorf("label must be of the form <name>=<value>, got %s", strconv.Quote(l))
+		}
+		lset.Labels = append(lset.Labels, &profilestorepb.Label{Name: name, Value: value})
+	}
+
+	return lset, nil
+}
+
+// maxBodySize is the maximum size of a request body, decompressed, like the
+// maximum size of the gRPC messages received.
+const maxBodySize = 32 << 20
+
+// errBodyTooLarge is returned for request bodies larger than maxBodySize,
+// before or after decompression.
+var errBodyTooLarge = fmt.Errorf("request body exceeds %d bytes", maxBodySize)
+
+// readBody reads the body of the request, decompressing it if it is gzip
+// encoded. Bodies larger than maxBodySize, before or after decompression, are
+// rejected with errBodyTooLarge.
+func readBody(r *http.Request) ([]byte, error) {
+	body := io.Reader(&limitedReader{r: r.Body, n: maxBodySize})
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gr, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gr.Close()
+		body = &limitedReader{r: gr, n: maxBodySize}
+	}
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+
+	return b, nil
+}
+
+// readBodyStatusCode returns the HTTP status code of the error returned by
+// readBody.
+func readBodyStatusCode(err error) int {
+	if errors.Is(err, errBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// limitedReader reads at most n bytes of r, and fails with errBodyTooLarge if
+// r has more. Unlike http.MaxBytesReader, its error can be told apart from
+// other read errors.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		n = int(l.n)
+		l.n = -1
+		return n, errBodyTooLarge
+	}
+	l.n -= int64(n)
+
+	return n, err
+}
+
+// requestContext returns the context of the request with the tenant of its
+// tenant header, if any.
+func requestContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	if t := r.Header.Get(tenant.Header); t != "" {
+		ctx = tenant.NewContext(ctx, t)
+	}
+	return ctx
+}
+
+// httpStatusCode returns the HTTP status code of the error returned by the
+// profile store.
+func httpStatusCode(err error) int {
+	if status.Code(err) == codes.InvalidArgument {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
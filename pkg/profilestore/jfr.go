@@ -0,0 +1,79 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"net/http"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"google.golang.org/grpc/status"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+)
+
+// JFRPath is the path Java Flight Recorder recordings are pushed to over
+// HTTP.
+const JFRPath = "/ingest/jfr"
+
+// JFRHandler accepts Java Flight Recorder recordings pushed over HTTP and
+// writes them to the profile store, e.g.
+//
+//	curl --data-binary @recording.jfr 'http://localhost:7070/ingest/jfr?label=job=api'
+type JFRHandler struct {
+	logger log.Logger
+	store  profilestorepb.ProfileStoreServiceServer
+}
+
+func NewJFRHandler(logger log.Logger, store profilestorepb.ProfileStoreServiceServer) *JFRHandler {
+	return &JFRHandler{
+		logger: logger,
+		store:  store,
+	}
+}
+
+// ServeHTTP writes the recording of the request body, optionally gzip
+// compressed. The labels of the series are set by label query parameters of
+// the form <name>=<value>, and the tenant is selected by the tenant header.
+func (h *JFRHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lset, err := labelSetFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b, err := readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), readBodyStatusCode(err))
+		return
+	}
+
+	req := &profilestorepb.WriteJFRRequest{
+		Labels: lset,
+		Jfr:    b,
+	}
+	if _, err := h.store.WriteJFR(requestContext(r), req); err != nil {
+		level.Debug(h.logger).Log("msg", "failed to write jfr recording", "err", err)
+		http.Error(w, status.Convert(err).Message(), httpStatusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,292 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"go.opentelemetry.io/otel/trace"
+	otlpcommonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
+	otlpcollectorpb "github.com/parca-dev/parca/gen/proto/go/parca/otlp/collector/profiles/v1development"
+	otlpprofilespb "github.com/parca-dev/parca/gen/proto/go/parca/otlp/profiles/v1development"
+	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/tenant"
+)
+
+// OTLPProfilesPath is the path OTLP/HTTP profiles are exported to.
+const OTLPProfilesPath = "/v1development/profiles"
+
+// otlpProfilesServiceName is the name of the profiles service in the OTLP
+// protocol. The vendored protos are in a package of their own, so the service
+// is registered under this name for OTLP/gRPC exporters to find it.
+const otlpProfilesServiceName = "opentelemetry.proto.collector.profiles.v1development.ProfilesService"
+
+// RegisterOTLPProfilesServiceServer registers the profiles service under its
+// name in the OTLP protocol.
+func RegisterOTLPProfilesServiceServer(s grpc.ServiceRegistrar, srv otlpcollectorpb.ProfilesServiceServer) {
+	desc := otlpcollectorpb.ProfilesService_ServiceDesc
+	desc.ServiceName = otlpProfilesServiceName
+	s.RegisterService(&desc, srv)
+}
+
+// OTLPReceiver receives profiles exported by OpenTelemetry instrumented
+// services, over either OTLP/gRPC or OTLP/HTTP. Like Arrow records, they are
+// not recorded in the write-ahead log.
+type OTLPReceiver struct {
+	otlpcollectorpb.UnimplementedProfilesServiceServer
+
+	logger log.Logger
+	tracer trace.Tracer
+
+	// store is the profile store profiles are ingested through, so that
+	// ingesting them does not overlap a checkpoint of its write-ahead log.
+	store *ProfileColumnStore
+}
+
+var _ otlpcollectorpb.ProfilesServiceServer = &OTLPReceiver{}
+
+func NewOTLPReceiver(
+	logger log.Logger,
+	tracer trace.Tracer,
+	store *ProfileColumnStore,
+) *OTLPReceiver {
+	return &OTLPReceiver{
+		logger: logger,
+		tracer: tracer,
+		store:  store,
+	}
+}
+
+// Export ingests every profile of the request, labeled with the attributes of
+// its resource and its own attributes. Profiles failing to be converted or
+// ingested do not fail the request, they are reported as rejected in its
+// partial success.
+func (r *OTLPReceiver) Export(ctx context.Context, req *otlpcollectorpb.ExportProfilesServiceRequest) (*otlpcollectorpb.ExportProfilesServiceResponse, error) {
+	ctx, span := r.tracer.Start(ctx, "otlp-export")
+	defer span.End()
+
+	if err := tenant.Validate(tenant.FromContext(ctx)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ingester := parcacol.NewIngester(r.logger, parcacol.NewNormalizer(r.store.metastore), r.store.table)
+
+	var (
+		rejected int64
+		lastErr  error
+	)
+	for _, rp := range req.ResourceProfiles {
+		for _, sp := range rp.ScopeProfiles {
+			for _, p := range sp.Profiles {
+				err := r.store.ingest(func() error {
+					return r.ingest(ctx, ingester, req.Dictionary, rp.Resource.GetAttributes(), p)
+				})
+				if err != nil {
+					level.Warn(r.logger).Log("msg", "failed to ingest otlp profile", "err", err)
+					rejected++
+					lastErr = err
+				}
+			}
+		}
+	}
+
+	res := &otlpcollectorpb.ExportProfilesServiceResponse{}
+	if rejected > 0 {
+		res.PartialSuccess = &otlpcollectorpb.ExportProfilesPartialSuccess{
+			RejectedProfiles: rejected,
+			ErrorMessage:     lastErr.Error(),
+		}
+	}
+
+	return res, nil
+}
+
+func (r *OTLPReceiver) ingest(
+	ctx context.Context,
+	ingester *parcacol.Ingester,
+	d *otlpprofilespb.ProfilesDictionary,
+	resourceAttrs []*otlpcommonpb.KeyValue,
+	p *otlpprofilespb.Profile,
+) error {
+	pp, err := parcacol.OTLPProfileToPprof(d, p)
+	if err != nil {
+		return fmt.Errorf("convert profile: %w", err)
+	}
+
+	profileAttrs, err := parcacol.OTLPAttributes(d, p.AttributeIndices)
+	if err != nil {
+		return fmt.Errorf("convert profile: %w", err)
+	}
+
+	ls := otlpSeriesLabels(pp, resourceAttrs, profileAttrs)
+	if err := ingester.Ingest(ctx, ls, pp, false); err != nil {
+		return fmt.Errorf("ingest profile: %w", err)
+	}
+
+	return nil
+}
+
+// otlpSeriesLabels returns the labels of the series of the profile. Attribute
+// keys are sanitized into label names, e.g. service.name becomes
+// service_name, and attributes of the profile take precedence over the ones
+// of its resource. The name of the series is derived from the period type of
+// the profile the same way it is for scraped profiles.
+func otlpSeriesLabels(p *pprofpb.Profile, resourceAttrs, profileAttrs []*otlpcommonpb.KeyValue) labels.Labels {
+	m := map[string]string{}
+	for _, attrs := range [][]*otlpcommonpb.KeyValue{resourceAttrs, profileAttrs} {
+		for _, attr := range attrs {
+			name := sanitizeLabelName(attr.Key)
+			if name == "" || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+				continue
+			}
+			if v, ok := parcacol.OTLPAttributeValue(attr.Value); ok && v != "" {
+				m[name] = v
+			}
+		}
+	}
+
+	ls := make(labels.Labels, 0, len(m)+1)
+	for name, value := range m {
+		ls = append(ls, labels.Label{Name: name, Value: value})
+	}
+	ls = append(ls, labels.Label{Name: model.MetricNameLabel, Value: otlpProfileName(p)})
+	sort.Sort(ls)
+
+	return ls
+}
+
+// otlpProfileName returns the name of the profile, derived from its period
+// type or, if it has none, the type of its first sample type.
+func otlpProfileName(p *pprofpb.Profile) string {
+	name := ""
+	if p.PeriodType != nil {
+		name = p.StringTable[p.PeriodType.Type]
+	}
+	if name == "" && len(p.SampleType) > 0 {
+		name = p.StringTable[p.SampleType[0].Type]
+	}
+
+	switch name {
+	case "cpu":
+		return "process_cpu"
+	case "space":
+		return "memory"
+	case "":
+		return "unknown"
+	default:
+		return sanitizeLabelName(name)
+	}
+}
+
+// sanitizeLabelName replaces all characters not allowed in label names with
+// underscores.
+func sanitizeLabelName(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	b := []byte(name)
+	for i, c := range b {
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9' && i > 0)) {
+			b[i] = '_'
+		}
+	}
+
+	return string(b)
+}
+
+// ServeHTTP implements OTLP/HTTP, accepting export requests encoded as either
+// binary protobuf or JSON, optionally gzip compressed. The response is
+// encoded the same way as the request, and so are errors, as status messages.
+// The tenant is selected by the tenant header.
+func (r *OTLPReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, "invalid content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var (
+		unmarshal func([]byte, proto.Message) error
+		marshal   func(proto.Message) ([]byte, error)
+	)
+	switch contentType {
+	case "application/x-protobuf":
+		unmarshal, marshal = proto.Unmarshal, proto.Marshal
+	case "application/json":
+		unmarshal, marshal = protojson.Unmarshal, protojson.Marshal
+	default:
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	write := func(code int, m proto.Message) {
+		b, err := marshal(m)
+		if err != nil {
+			level.Error(r.logger).Log("msg", "failed to marshal otlp response", "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(code)
+		if _, err := w.Write(b); err != nil {
+			level.Debug(r.logger).Log("msg", "failed to write otlp response", "err", err)
+		}
+	}
+	writeErr := func(err error) {
+		write(httpStatusCode(err), status.Convert(err).Proto())
+	}
+
+	b, err := readBody(req)
+	if err != nil {
+		write(readBodyStatusCode(err), status.New(codes.InvalidArgument, err.Error()).Proto())
+		return
+	}
+
+	exportReq := &otlpcollectorpb.ExportProfilesServiceRequest{}
+	if err := unmarshal(b, exportReq); err != nil {
+		writeErr(status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err))
+		return
+	}
+
+	res, err := r.Export(requestContext(req), exportReq)
+	if err != nil {
+		writeErr(err)
+		return
+	}
+
+	write(http.StatusOK, res)
+}
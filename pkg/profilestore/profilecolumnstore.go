@@ -18,11 +18,18 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/apache/arrow/go/v8/arrow/ipc"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/polarsignals/frostdb"
@@ -36,7 +43,10 @@ import (
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
 	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/jfr"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type ProfileColumnStore struct {
@@ -54,6 +64,30 @@ type ProfileColumnStore struct {
 	// reproducing situations in tests. This has huge overhead, do not enable
 	// unless you know what you're doing.
 	debugValueLog bool
+
+	// wal records every accepted write request, so that profiles not yet
+	// persisted by the columnstore can be replayed after a crash. It is nil
+	// when the write-ahead log is disabled.
+	wal *WAL
+
+	// walMtx is held for reading while profiles are ingested, and for
+	// writing while the write-ahead log is checkpointed, so that a checkpoint
+	// neither misses a logged request still being ingested nor races the
+	// columnstore rotating a block.
+	walMtx sync.RWMutex
+	// walBlocks are the blocks profiles were ingested into since the last
+	// checkpoint, from the oldest to the active one, guarded by walBlocksMtx.
+	walBlocksMtx sync.Mutex
+	walBlocks    []*frostdb.TableBlock
+	// walCheckpoints is notified whenever the columnstore rotated a block.
+	walCheckpoints chan struct{}
+
+	// ingestWorkers limits the number of series of streams ingested
+	// concurrently, and ingestQueue the number of series of streams accepted
+	// but not yet ingested, including the ones being ingested. Streams are
+	// rejected once the queue is full.
+	ingestWorkers chan struct{}
+	ingestQueue   chan struct{}
 }
 
 var _ profilestorepb.ProfileStoreServiceServer = &ProfileColumnStore{}
@@ -64,13 +98,20 @@ func NewProfileColumnStore(
 	metastore metastorepb.MetastoreServiceClient,
 	table *frostdb.Table,
 	debugValueLog bool,
+	wal *WAL,
+	ingestWorkers int,
+	ingestQueueSize int,
 ) *ProfileColumnStore {
 	return &ProfileColumnStore{
-		logger:        logger,
-		tracer:        tracer,
-		metastore:     metastore,
-		table:         table,
-		debugValueLog: debugValueLog,
+		logger:         logger,
+		tracer:         tracer,
+		metastore:      metastore,
+		table:          table,
+		debugValueLog:  debugValueLog,
+		wal:            wal,
+		walCheckpoints: make(chan struct{}, 1),
+		ingestWorkers:  make(chan struct{}, ingestWorkers),
+		ingestQueue:    make(chan struct{}, ingestWorkers+ingestQueueSize),
 	}
 }
 
@@ -78,13 +119,445 @@ func (s *ProfileColumnStore) WriteRaw(ctx context.Context, req *profilestorepb.W
 	ctx, span := s.tracer.Start(ctx, "write-raw")
 	defer span.End()
 
+	// The tenant of the request metadata takes precedence over the tenant of
+	// the request, which is recorded so the write-ahead log preserves it.
+	if t := tenant.FromContext(ctx); t != "" {
+		req.Tenant = t
+	}
+
+	if err := s.write(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return &profilestorepb.WriteRawResponse{}, nil
+}
+
+// WriteStream ingests the series of the stream through the ingest worker
+// pool. The stream is aborted with RESOURCE_EXHAUSTED if the ingest queue is
+// full, once the series queued before are ingested. The stats of the stream up
+// to then are attached to the status as a WriteStreamResponse, the series the
+// stream was aborted at is neither ingested nor failed. Series failing to be
+// ingested do not abort the stream, they are counted in the stats returned
+// once the stream is closed.
+func (s *ProfileColumnStore) WriteStream(stream profilestorepb.ProfileStoreService_WriteStreamServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "write-stream")
+	defer span.End()
+
+	t := tenant.FromContext(ctx)
+	if err := tenant.Validate(t); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		wg sync.WaitGroup
+
+		received uint64
+		ingested uint64
+		failed   uint64
+	)
+	// In-flight series are always ingested before returning, as their
+	// context is canceled once the stream is done.
+	defer wg.Wait()
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		received++
+
+		if req.Series == nil {
+			atomic.AddUint64(&failed, 1)
+			continue
+		}
+
+		select {
+		case s.ingestQueue <- struct{}{}:
+		default:
+			wg.Wait()
+			st, err := status.Newf(codes.ResourceExhausted, "ingest queue is full, %d series of the stream were received", received-1).
+				WithDetails(&profilestorepb.WriteStreamResponse{
+					ReceivedSeries: received,
+					IngestedSeries: atomic.LoadUint64(&ingested),
+					FailedSeries:   atomic.LoadUint64(&failed),
+				})
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to attach stats to status: %v", err)
+			}
+			return st.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-s.ingestQueue }()
+
+			s.ingestWorkers <- struct{}{}
+			defer func() { <-s.ingestWorkers }()
+
+			err := s.write(ctx, &profilestorepb.WriteRawRequest{
+				Tenant:     t,
+				Series:     []*profilestorepb.RawProfileSeries{req.Series},
+				Normalized: req.Normalized,
+			})
+			if err != nil {
+				level.Warn(s.logger).Log("msg", "failed to ingest series of stream", "err", err)
+				atomic.AddUint64(&failed, 1)
+				return
+			}
+			atomic.AddUint64(&ingested, 1)
+		}()
+	}
+
+	wg.Wait()
+	return stream.SendAndClose(&profilestorepb.WriteStreamResponse{
+		ReceivedSeries: received,
+		IngestedSeries: atomic.LoadUint64(&ingested),
+		FailedSeries:   atomic.LoadUint64(&failed),
+	})
+}
+
+// WriteArrow inserts the Arrow record batches of the request as they are.
+// Unlike raw profiles, they are not recorded in the write-ahead log.
+func (s *ProfileColumnStore) WriteArrow(ctx context.Context, req *profilestorepb.WriteArrowRequest) (*profilestorepb.WriteArrowResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "write-arrow")
+	defer span.End()
+
+	t := tenant.FromContext(ctx)
+	if err := tenant.Validate(t); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	r, err := ipc.NewReader(bytes.NewReader(req.Record))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read arrow record: %v", err)
+	}
+	defer r.Release()
+
+	ingester := parcacol.NewIngester(s.logger, parcacol.NewNormalizer(s.metastore), s.table)
+
+	err = s.ingest(func() error {
+		for r.Next() {
+			buffer, err := parcacol.ArrowRecordToParquetBuffer(ctx, s.metastore, s.table.Schema(), t, r.Record())
+			if _, ok := status.FromError(err); !ok {
+				return status.Errorf(codes.InvalidArgument, "failed to convert arrow record: %v", err)
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := ingester.IngestBuffer(ctx, buffer); err != nil {
+				return status.Errorf(codes.Internal, "failed to ingest arrow record: %v", err)
+			}
+		}
+		if err := r.Err(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to read arrow record: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &profilestorepb.WriteArrowResponse{}, nil
+}
+
+// WriteFolded writes the folded stacks of the request as samples of its
+// profile type. Like Arrow records, they are not recorded in the write-ahead
+// log.
+func (s *ProfileColumnStore) WriteFolded(ctx context.Context, req *profilestorepb.WriteFoldedRequest) (*profilestorepb.WriteFoldedResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "write-folded")
+	defer span.End()
+
+	if err := tenant.Validate(tenant.FromContext(ctx)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	meta, err := metaFromProfileType(req.ProfileType)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	meta.Timestamp = req.Timestamp
+	if meta.Timestamp == 0 {
+		meta.Timestamp = timestamp.FromTime(time.Now())
+	}
+	meta.Duration = req.Duration
+	meta.Period = req.Period
+
+	ls, err := seriesLabels(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizer := parcacol.NewNormalizer(s.metastore)
+	p, err := normalizer.NormalizeFolded(ctx, meta, req.Folded)
+	if errors.Is(err, parcacol.ErrInvalidFoldedStacks) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to normalize folded stacks: %v", err)
+	}
+
+	if len(p.Samples) > 0 {
+		ingester := parcacol.NewIngester(s.logger, normalizer, s.table)
+		if err := s.ingest(func() error { return ingester.IngestProfile(ctx, ls, p) }); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to ingest folded stacks: %v", err)
+		}
+	}
+
+	return &profilestorepb.WriteFoldedResponse{}, nil
+}
+
+// WriteJFR writes the execution samples, allocation samples and lock
+// contention events of a Java Flight Recorder recording, each as a profile of
+// its own, e.g. jfr_cpu:samples:count:cpu:nanoseconds. Like Arrow records,
+// recordings are not recorded in the write-ahead log.
+func (s *ProfileColumnStore) WriteJFR(ctx context.Context, req *profilestorepb.WriteJFRRequest) (*profilestorepb.WriteJFRResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "write-jfr")
+	defer span.End()
+
+	if err := tenant.Validate(tenant.FromContext(ctx)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ls, err := seriesLabels(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := jfr.Parse(req.Jfr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	normalizer := parcacol.NewNormalizer(s.metastore)
+	profiles, err := normalizer.NormalizeJFR(ctx, rec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to normalize jfr recording: %v", err)
+	}
+
+	ingester := parcacol.NewIngester(s.logger, normalizer, s.table)
+	err = s.ingest(func() error {
+		for _, p := range profiles {
+			if err := ingester.IngestProfile(ctx, ls, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to ingest jfr recording: %v", err)
+	}
+
+	return &profilestorepb.WriteJFRResponse{}, nil
+}
+
+// seriesLabels returns the sorted labels of the label set, whose name is set
+// by the profile type of the samples written to them.
+func seriesLabels(lset *profilestorepb.LabelSet) (labels.Labels, error) {
+	ls := make(labels.Labels, 0, len(lset.GetLabels()))
+	for _, l := range lset.GetLabels() {
+		if valid := model.LabelName(l.Name).IsValid(); !valid {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %v", l.Name)
+		}
+		if l.Name == model.MetricNameLabel {
+			return nil, status.Errorf(codes.InvalidArgument, "the %s label is taken from the profile type", model.MetricNameLabel)
+		}
+
+		ls = append(ls, labels.Label{
+			Name:  l.Name,
+			Value: l.Value,
+		})
+	}
+	sort.Sort(ls)
+
+	return ls, nil
+}
+
+// metaFromProfileType returns the meta of profiles of the type, of the form
+// <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>.
+func metaFromProfileType(profileType string) (profile.Meta, error) {
+	parts := strings.Split(profileType, ":")
+	if len(parts) != 5 {
+		return profile.Meta{}, fmt.Errorf("profile type must be of the form <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>, got %q", profileType)
+	}
+	if !model.IsValidMetricName(model.LabelValue(parts[0])) {
+		return profile.Meta{}, fmt.Errorf("invalid profile name: %v", parts[0])
+	}
+
+	return profile.Meta{
+		Name:       parts[0],
+		SampleType: profile.ValueType{Type: parts[1], Unit: parts[2]},
+		PeriodType: profile.ValueType{Type: parts[3], Unit: parts[4]},
+	}, nil
+}
+
+// write records the request in the write-ahead log and ingests it. The
+// request is logged first, so that it is not lost if a crash interrupts
+// ingesting it.
+func (s *ProfileColumnStore) write(ctx context.Context, req *profilestorepb.WriteRawRequest) error {
+	return s.ingest(func() error {
+		if s.wal != nil {
+			if err := s.wal.Log(req); err != nil {
+				return status.Errorf(codes.Internal, "failed to write to wal: %v", err)
+			}
+		}
+
+		return s.writeRaw(ctx, req)
+	})
+}
+
+// ingest runs fn, which ingests profiles into the table, so that it does not
+// overlap a checkpoint of the write-ahead log.
+func (s *ProfileColumnStore) ingest(fn func() error) error {
+	s.walMtx.RLock()
+	defer s.walMtx.RUnlock()
+
+	// The columnstore only rotates blocks when profiles are ingested, so
+	// noting the active block before and after notes every block fn ingested
+	// into.
+	s.trackActiveBlock()
+	defer s.trackActiveBlock()
+
+	return fn()
+}
+
+// trackActiveBlock notes the active block of the table for the next
+// checkpoint of the write-ahead log, which is triggered if the columnstore
+// rotated the block noted before.
+func (s *ProfileColumnStore) trackActiveBlock() {
+	if s.wal == nil {
+		return
+	}
+
+	block := s.table.ActiveBlock()
+
+	s.walBlocksMtx.Lock()
+	defer s.walBlocksMtx.Unlock()
+
+	n := len(s.walBlocks)
+	if n > 0 && s.walBlocks[n-1] == block {
+		return
+	}
+	s.walBlocks = append(s.walBlocks, block)
+
+	if n > 0 {
+		select {
+		case s.walCheckpoints <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// CheckpointWAL persists the blocks profiles were ingested into since the
+// last checkpoint and removes the requests ingested into them from the
+// write-ahead log. The active block is persisted in place, it is persisted
+// again once the columnstore rotates it. Rotated blocks are persisted again
+// rather than waiting for the columnstore to persist them, as it does not
+// tell when it is done, both write the same data.
+func (s *ProfileColumnStore) CheckpointWAL() error {
+	if s.wal == nil {
+		return nil
+	}
+
+	s.walMtx.Lock()
+	offset, err := s.wal.Size()
+	if err != nil {
+		s.walMtx.Unlock()
+		return fmt.Errorf("get wal size: %w", err)
+	}
+
+	active := s.table.ActiveBlock()
+	blocks := s.walBlocks
+	s.walBlocks = []*frostdb.TableBlock{active}
+
+	active.Sync()
+	if active.Size() > 0 {
+		err = active.Persist()
+	}
+	s.walMtx.Unlock()
+
+	if err == nil {
+		for _, block := range blocks {
+			if block == active {
+				continue
+			}
+			block.Sync()
+			if err = block.Persist(); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		// The blocks are persisted again by the next checkpoint.
+		s.walBlocksMtx.Lock()
+		s.walBlocks = append(blocks, s.walBlocks...)
+		s.walBlocksMtx.Unlock()
+		return fmt.Errorf("persist block: %w", err)
+	}
+
+	if err := s.wal.TruncateBefore(offset); err != nil {
+		return fmt.Errorf("truncate wal: %w", err)
+	}
+
+	return nil
+}
+
+// RunWALCheckpoints checkpoints the write-ahead log whenever the columnstore
+// rotates a block, until the context is canceled.
+func (s *ProfileColumnStore) RunWALCheckpoints(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.walCheckpoints:
+			if err := s.CheckpointWAL(); err != nil {
+				level.Error(s.logger).Log("msg", "failed to checkpoint wal", "err", err)
+			}
+		}
+	}
+}
+
+// ReplayWAL ingests all requests recorded in the write-ahead log. Requests
+// that fail to be ingested are skipped.
+func (s *ProfileColumnStore) ReplayWAL(ctx context.Context) error {
+	if s.wal == nil {
+		return nil
+	}
+
+	n := 0
+	err := s.wal.Replay(func(req *profilestorepb.WriteRawRequest) error {
+		if err := s.ingest(func() error { return s.writeRaw(ctx, req) }); err != nil {
+			level.Warn(s.logger).Log("msg", "failed to replay write request from wal", "err", err)
+			return nil
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	level.Info(s.logger).Log("msg", "replayed wal", "requests", n)
+	return nil
+}
+
+func (s *ProfileColumnStore) writeRaw(ctx context.Context, req *profilestorepb.WriteRawRequest) error {
+	if err := tenant.Validate(req.Tenant); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx = tenant.NewContext(ctx, req.Tenant)
+
 	ingester := parcacol.NewIngester(s.logger, parcacol.NewNormalizer(s.metastore), s.table)
 
 	for _, series := range req.Series {
 		ls := make(labels.Labels, 0, len(series.Labels.Labels))
 		for _, l := range series.Labels.Labels {
 			if valid := model.LabelName(l.Name).IsValid(); !valid {
-				return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %v", l.Name)
+				return status.Errorf(codes.InvalidArgument, "invalid label name: %v", l.Name)
 			}
 
 			ls = append(ls, labels.Label{
@@ -96,17 +569,17 @@ func (s *ProfileColumnStore) WriteRaw(ctx context.Context, req *profilestorepb.W
 		for _, sample := range series.Samples {
 			r, err := gzip.NewReader(bytes.NewBuffer(sample.RawProfile))
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to create gzip reader: %v", err)
+				return status.Errorf(codes.Internal, "failed to create gzip reader: %v", err)
 			}
 
 			content, err := ioutil.ReadAll(r)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to decompress profile: %v", err)
+				return status.Errorf(codes.Internal, "failed to decompress profile: %v", err)
 			}
 
 			p := &pprofpb.Profile{}
 			if err := p.UnmarshalVT(content); err != nil {
-				return nil, status.Errorf(codes.InvalidArgument, "failed to parse profile: %v", err)
+				return status.Errorf(codes.InvalidArgument, "failed to parse profile: %v", err)
 			}
 
 			if s.debugValueLog {
@@ -123,10 +596,10 @@ func (s *ProfileColumnStore) WriteRaw(ctx context.Context, req *profilestorepb.W
 			}
 
 			if err := ingester.Ingest(ctx, ls, p, req.Normalized); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
+				return status.Errorf(codes.Internal, "failed to ingest profile: %v", err)
 			}
 		}
 	}
 
-	return &profilestorepb.WriteRawResponse{}, nil
+	return nil
 }
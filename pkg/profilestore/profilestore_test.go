@@ -14,27 +14,52 @@
 package profilestore
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
+	"github.com/apache/arrow/go/v8/arrow/ipc"
+	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/go-kit/log"
 	"github.com/polarsignals/frostdb"
+	"github.com/polarsignals/frostdb/query/logicalplan"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/objstore"
 	"go.opentelemetry.io/otel/trace"
+	otlpcommonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	otlpresourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
+	metastorepb "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	otlpcollectorpb "github.com/parca-dev/parca/gen/proto/go/parca/otlp/collector/profiles/v1development"
+	otlpprofilespb "github.com/parca-dev/parca/gen/proto/go/parca/otlp/profiles/v1development"
 	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+	"github.com/parca-dev/parca/pkg/jfr"
+	"github.com/parca-dev/parca/pkg/jfr/jfrtest"
 	"github.com/parca-dev/parca/pkg/metastore"
 	"github.com/parca-dev/parca/pkg/metastoretest"
 	"github.com/parca-dev/parca/pkg/parcacol"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
-func Test_LabelName_Invalid(t *testing.T) {
-	t.Parallel()
+func newTestProfileColumnStore(t *testing.T, ingestWorkers, ingestQueueSize int) *ProfileColumnStore {
+	t.Helper()
 
-	ctx := context.Background()
 	logger := log.NewNopLogger()
 	reg := prometheus.NewRegistry()
 	tracer := trace.NewNoopTracerProvider().Tracer("")
@@ -60,13 +85,23 @@ func Test_LabelName_Invalid(t *testing.T) {
 		tracer,
 	)
 
-	api := NewProfileColumnStore(
+	return NewProfileColumnStore(
 		logger,
 		tracer,
 		metastore.NewInProcessClient(m),
 		table,
 		false,
+		nil,
+		ingestWorkers,
+		ingestQueueSize,
 	)
+}
+
+func Test_LabelName_Invalid(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 1, 0)
 
 	req := &profilestorepb.WriteRawRequest{
 		Series: []*profilestorepb.RawProfileSeries{{
@@ -79,8 +114,647 @@ func Test_LabelName_Invalid(t *testing.T) {
 		}},
 	}
 
-	_, err = api.WriteRaw(ctx, req)
+	_, err := api.WriteRaw(ctx, req)
 	st, _ := status.FromError(err)
 
 	require.Equal(t, st.Code(), codes.InvalidArgument)
 }
+
+type fakeWriteStream struct {
+	grpc.ServerStream
+
+	ctx  context.Context
+	reqs []*profilestorepb.WriteStreamRequest
+	resp *profilestorepb.WriteStreamResponse
+}
+
+func (s *fakeWriteStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWriteStream) Recv() (*profilestorepb.WriteStreamRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *fakeWriteStream) SendAndClose(resp *profilestorepb.WriteStreamResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func testWriteStreamRequest(t *testing.T, labelName string) *profilestorepb.WriteStreamRequest {
+	t.Helper()
+
+	raw, err := os.ReadFile("../query/testdata/alloc_objects.pb.gz")
+	require.NoError(t, err)
+
+	return &profilestorepb.WriteStreamRequest{
+		Series: &profilestorepb.RawProfileSeries{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{
+					Name:  "__name__",
+					Value: "memory",
+				}, {
+					Name:  labelName,
+					Value: "v0",
+				}},
+			},
+			Samples: []*profilestorepb.RawSample{{
+				RawProfile: raw,
+			}},
+		},
+	}
+}
+
+func TestWriteStream(t *testing.T) {
+	t.Parallel()
+
+	api := newTestProfileColumnStore(t, 2, 4)
+
+	stream := &fakeWriteStream{
+		ctx: context.Background(),
+		reqs: []*profilestorepb.WriteStreamRequest{
+			testWriteStreamRequest(t, "job"),
+			testWriteStreamRequest(t, "n0:n"),
+			testWriteStreamRequest(t, "instance"),
+			{},
+		},
+	}
+	require.NoError(t, api.WriteStream(stream))
+	require.Equal(t, &profilestorepb.WriteStreamResponse{
+		ReceivedSeries: 4,
+		IngestedSeries: 2,
+		FailedSeries:   2,
+	}, stream.resp)
+}
+
+func TestWriteStream_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	api := newTestProfileColumnStore(t, 1, 0)
+
+	// Occupy the only slot of the queue.
+	api.ingestQueue <- struct{}{}
+
+	stream := &fakeWriteStream{
+		ctx: context.Background(),
+		reqs: []*profilestorepb.WriteStreamRequest{
+			{},
+			testWriteStreamRequest(t, "job"),
+		},
+	}
+	err := api.WriteStream(stream)
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Nil(t, stream.resp)
+
+	// The stats of the stream up to the abort are attached to the status.
+	require.Len(t, st.Details(), 1)
+	stats, ok := st.Details()[0].(*profilestorepb.WriteStreamResponse)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), stats.ReceivedSeries)
+	require.Equal(t, uint64(0), stats.IngestedSeries)
+	require.Equal(t, uint64(1), stats.FailedSeries)
+
+	<-api.ingestQueue
+
+	stream.reqs = []*profilestorepb.WriteStreamRequest{
+		testWriteStreamRequest(t, "job"),
+	}
+	require.NoError(t, api.WriteStream(stream))
+	require.Equal(t, uint64(1), stream.resp.IngestedSeries)
+}
+
+func testArrowRecord(t *testing.T, tenantColumn bool, stacktraceIDs []string) []byte {
+	t.Helper()
+
+	fields := []arrow.Field{
+		{Name: parcacol.ColumnDuration, Type: arrow.PrimitiveTypes.Int64},
+		{Name: "labels.job", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: parcacol.ColumnName, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnPeriod, Type: arrow.PrimitiveTypes.Int64},
+		{Name: parcacol.ColumnPeriodType, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnPeriodUnit, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnSampleType, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnSampleUnit, Type: arrow.BinaryTypes.String},
+		{Name: parcacol.ColumnStacktrace, Type: arrow.BinaryTypes.Binary},
+		{Name: parcacol.ColumnTimestamp, Type: arrow.PrimitiveTypes.Int64},
+		{Name: parcacol.ColumnValue, Type: arrow.PrimitiveTypes.Int64},
+	}
+	if tenantColumn {
+		fields = append(fields, arrow.Field{Name: parcacol.ColumnTenant, Type: arrow.BinaryTypes.String})
+	}
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, arrow.NewSchema(fields, nil))
+	defer b.Release()
+
+	for i, id := range stacktraceIDs {
+		b.Field(0).(*array.Int64Builder).Append(0)
+		b.Field(1).(*array.StringBuilder).Append("test")
+		b.Field(2).(*array.StringBuilder).Append("memory")
+		b.Field(3).(*array.Int64Builder).Append(524288)
+		b.Field(4).(*array.StringBuilder).Append("space")
+		b.Field(5).(*array.StringBuilder).Append("bytes")
+		b.Field(6).(*array.StringBuilder).Append("alloc_objects")
+		b.Field(7).(*array.StringBuilder).Append("count")
+		b.Field(8).(*array.BinaryBuilder).AppendString(id)
+		b.Field(9).(*array.Int64Builder).Append(1)
+		b.Field(10).(*array.Int64Builder).Append(int64(i + 1))
+		if tenantColumn {
+			b.Field(11).(*array.StringBuilder).Append("other")
+		}
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	buf := &bytes.Buffer{}
+	w := ipc.NewWriter(buf, ipc.WithSchema(rec.Schema()))
+	require.NoError(t, w.Write(rec))
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestWriteArrow(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 1, 0)
+
+	lres, err := api.metastore.GetOrCreateLocations(ctx, &metastorepb.GetOrCreateLocationsRequest{
+		Locations: []*metastorepb.Location{{Address: 0x1}, {Address: 0x2}},
+	})
+	require.NoError(t, err)
+	sres, err := api.metastore.GetOrCreateStacktraces(ctx, &metastorepb.GetOrCreateStacktracesRequest{
+		Stacktraces: []*metastorepb.Stacktrace{
+			{LocationIds: []string{lres.Locations[0].Id}},
+			{LocationIds: []string{lres.Locations[1].Id}},
+		},
+	})
+	require.NoError(t, err)
+	stacktraceIDs := []string{sres.Stacktraces[0].Id, sres.Stacktraces[1].Id}
+
+	_, err = api.WriteArrow(ctx, &profilestorepb.WriteArrowRequest{
+		Record: testArrowRecord(t, true, stacktraceIDs),
+	})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	// Samples of stacktraces unknown to the metastore are rejected.
+	_, err = api.WriteArrow(ctx, &profilestorepb.WriteArrowRequest{
+		Record: testArrowRecord(t, false, []string{stacktraceIDs[0], "unknown"}),
+	})
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Contains(t, st.Message(), "unknown stacktrace")
+
+	// Stacktraces are known to the metastore of their tenant only.
+	_, err = api.WriteArrow(tenant.NewContext(ctx, "other"), &profilestorepb.WriteArrowRequest{
+		Record: testArrowRecord(t, false, stacktraceIDs),
+	})
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	_, err = api.WriteArrow(ctx, &profilestorepb.WriteArrowRequest{
+		Record: testArrowRecord(t, false, stacktraceIDs),
+	})
+	require.NoError(t, err)
+
+	values := []int64{}
+	err = api.table.View(func(tx uint64) error {
+		return api.table.Iterator(
+			ctx,
+			tx,
+			memory.DefaultAllocator,
+			nil,
+			[]logicalplan.ColumnMatcher{
+				logicalplan.Col(parcacol.ColumnValue).Matcher(),
+			},
+			nil,
+			nil,
+			func(ar arrow.Record) error {
+				col := ar.Column(0).(*array.Int64)
+				for i := 0; i < col.Len(); i++ {
+					values = append(values, col.Value(i))
+				}
+				return nil
+			},
+		)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, values)
+}
+
+func testOTLPRequest(t *testing.T) []byte {
+	t.Helper()
+
+	mappingIndex := int32(0)
+	req := &otlpcollectorpb.ExportProfilesServiceRequest{
+		Dictionary: &otlpprofilespb.ProfilesDictionary{
+			StringTable: []string{"", "samples", "count", "cpu", "nanoseconds", "main", "runtime.main", "/bin/api"},
+			MappingTable: []*otlpprofilespb.Mapping{
+				{MemoryStart: 0x1000, MemoryLimit: 0x2000, FilenameStrindex: 7},
+			},
+			FunctionTable: []*otlpprofilespb.Function{
+				{NameStrindex: 5},
+				{NameStrindex: 6},
+			},
+			LocationTable: []*otlpprofilespb.Location{
+				{MappingIndex: &mappingIndex, Address: 0x1100, Line: []*otlpprofilespb.Line{{FunctionIndex: 0, Line: 10}}},
+				{MappingIndex: &mappingIndex, Address: 0x1200, Line: []*otlpprofilespb.Line{{FunctionIndex: 1, Line: 20}}},
+			},
+			AttributeTable: []*otlpcommonpb.KeyValue{
+				{Key: "thread.name", Value: &otlpcommonpb.AnyValue{Value: &otlpcommonpb.AnyValue_StringValue{StringValue: "worker"}}},
+			},
+		},
+		ResourceProfiles: []*otlpprofilespb.ResourceProfiles{{
+			Resource: &otlpresourcepb.Resource{
+				Attributes: []*otlpcommonpb.KeyValue{
+					{Key: "service.name", Value: &otlpcommonpb.AnyValue{Value: &otlpcommonpb.AnyValue_StringValue{StringValue: "api"}}},
+				},
+			},
+			ScopeProfiles: []*otlpprofilespb.ScopeProfiles{{
+				Profiles: []*otlpprofilespb.Profile{{
+					SampleType:      []*otlpprofilespb.ValueType{{TypeStrindex: 1, UnitStrindex: 2}},
+					PeriodType:      &otlpprofilespb.ValueType{TypeStrindex: 3, UnitStrindex: 4},
+					Period:          10000000,
+					TimeNanos:       1000000000,
+					LocationIndices: []int32{0, 1, 1},
+					Sample: []*otlpprofilespb.Sample{
+						{LocationsStartIndex: 0, LocationsLength: 2, Value: []int64{3}, AttributeIndices: []int32{0}},
+						{LocationsStartIndex: 2, LocationsLength: 1, TimestampsUnixNano: []uint64{1, 2}},
+					},
+				}, {
+					SampleType:      []*otlpprofilespb.ValueType{{TypeStrindex: 1, UnitStrindex: 2}},
+					LocationIndices: []int32{5},
+					Sample: []*otlpprofilespb.Sample{
+						{LocationsStartIndex: 0, LocationsLength: 1, Value: []int64{1}},
+					},
+				}},
+			}},
+		}},
+	}
+
+	b, err := req.MarshalVT()
+	require.NoError(t, err)
+	return b
+}
+
+func TestOTLPReceiver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 1, 0)
+	receiver := NewOTLPReceiver(api.logger, api.tracer, api)
+
+	r := httptest.NewRequest(http.MethodPost, OTLPProfilesPath, bytes.NewReader(testOTLPRequest(t)))
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	res := &otlpcollectorpb.ExportProfilesServiceResponse{}
+	require.NoError(t, res.UnmarshalVT(w.Body.Bytes()))
+	require.Equal(t, int64(1), res.PartialSuccess.GetRejectedProfiles())
+
+	r = httptest.NewRequest(http.MethodPost, OTLPProfilesPath, bytes.NewReader(make([]byte, maxBodySize+1)))
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	w = httptest.NewRecorder()
+	receiver.ServeHTTP(w, r)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	names := []string{}
+	services := []string{}
+	values := []int64{}
+	err := api.table.View(func(tx uint64) error {
+		return api.table.Iterator(
+			ctx,
+			tx,
+			memory.DefaultAllocator,
+			nil,
+			[]logicalplan.ColumnMatcher{
+				logicalplan.Col(parcacol.ColumnName).Matcher(),
+				logicalplan.Col(parcacol.ColumnLabels + ".service_name").Matcher(),
+				logicalplan.Col(parcacol.ColumnValue).Matcher(),
+			},
+			nil,
+			nil,
+			func(ar arrow.Record) error {
+				for i, field := range ar.Schema().Fields() {
+					switch col := ar.Column(i).(type) {
+					case *array.Int64:
+						values = append(values, col.Int64Values()...)
+					case *array.Binary:
+						for j := 0; j < col.Len(); j++ {
+							if field.Name == parcacol.ColumnName {
+								names = append(names, col.ValueString(j))
+							} else {
+								services = append(services, col.ValueString(j))
+							}
+						}
+					}
+				}
+				return nil
+			},
+		)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"process_cpu", "process_cpu"}, names)
+	require.Equal(t, []string{"api", "api"}, services)
+	require.ElementsMatch(t, []int64{3, 2}, values)
+}
+
+func TestOTLPReceiverGRPC(t *testing.T) {
+	t.Parallel()
+
+	api := newTestProfileColumnStore(t, 1, 0)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	t.Cleanup(srv.Stop)
+	RegisterOTLPProfilesServiceServer(srv, NewOTLPReceiver(api.logger, api.tracer, api))
+	go srv.Serve(lis)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	req := &otlpcollectorpb.ExportProfilesServiceRequest{}
+	require.NoError(t, req.UnmarshalVT(testOTLPRequest(t)))
+
+	// Exporters call the service by its name in the OTLP protocol.
+	res := &otlpcollectorpb.ExportProfilesServiceResponse{}
+	err = conn.Invoke(context.Background(), "/opentelemetry.proto.collector.profiles.v1development.ProfilesService/Export", req, res)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.PartialSuccess.GetRejectedProfiles())
+}
+
+func gzipBody(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	_, err := gw.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestReadBody(t *testing.T) {
+	t.Parallel()
+
+	large := make([]byte, maxBodySize+1)
+	// Random bytes grow when compressed.
+	random := make([]byte, maxBodySize)
+	_, err := rand.New(rand.NewSource(1)).Read(random)
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		name     string
+		body     []byte
+		gzip     bool
+		want     []byte
+		wantCode int
+	}{
+		{name: "plain", body: []byte("main;foo 1"), want: []byte("main;foo 1")},
+		{name: "gzip", body: gzipBody(t, []byte("main;foo 1")), gzip: true, want: []byte("main;foo 1")},
+		{name: "at limit", body: large[:maxBodySize], want: large[:maxBodySize]},
+		{name: "invalid gzip", body: []byte("main;foo 1"), gzip: true, wantCode: http.StatusBadRequest},
+		{name: "too large", body: large, wantCode: http.StatusRequestEntityTooLarge},
+		{name: "too large compressed", body: gzipBody(t, random), gzip: true, wantCode: http.StatusRequestEntityTooLarge},
+		{name: "too large decompressed", body: gzipBody(t, large), gzip: true, wantCode: http.StatusRequestEntityTooLarge},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.gzip {
+				r.Header.Set("Content-Encoding", "gzip")
+			}
+			b, err := readBody(r)
+			if tt.wantCode != 0 {
+				require.Error(t, err)
+				require.Equal(t, tt.wantCode, readBodyStatusCode(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, b)
+		})
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 1, 0)
+	h := NewFoldedHandler(api.logger, api)
+
+	for _, tc := range []struct {
+		query string
+		body  string
+		code  int
+	}{
+		{"profile_type=process_cpu:samples:count:cpu:nanoseconds", "main;foo 1x\n", http.StatusBadRequest},
+		{"profile_type=process_cpu:samples:count", "main;foo 1\n", http.StatusBadRequest},
+		{"profile_type=process_cpu:samples:count:cpu:nanoseconds&label=job", "main;foo 1\n", http.StatusBadRequest},
+		{"profile_type=process_cpu:samples:count:cpu:nanoseconds&label=job=api", strings.Repeat("main;foo 1\n", maxBodySize/10), http.StatusRequestEntityTooLarge},
+		{"profile_type=process_cpu:samples:count:cpu:nanoseconds&label=job=api", "main;foo;bar 3\nmain;foo 1\n\nmain;foo;bar 2\n", http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodPost, FoldedPath+"?"+tc.query, bytes.NewBufferString(tc.body))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, tc.code, w.Code, w.Body.String())
+	}
+
+	// Stacktraces are stored leaf first.
+	fres, err := api.metastore.GetOrCreateFunctions(ctx, &metastorepb.GetOrCreateFunctionsRequest{
+		Functions: []*metastorepb.Function{{Name: "bar"}, {Name: "foo"}, {Name: "main"}},
+	})
+	require.NoError(t, err)
+	locations := []*metastorepb.Location{}
+	for _, f := range fres.Functions {
+		locations = append(locations, &metastorepb.Location{
+			Lines: &metastorepb.LocationLines{Entries: []*metastorepb.Line{{FunctionId: f.Id}}},
+		})
+	}
+	lres, err := api.metastore.GetOrCreateLocations(ctx, &metastorepb.GetOrCreateLocationsRequest{Locations: locations})
+	require.NoError(t, err)
+	sres, err := api.metastore.GetOrCreateStacktraces(ctx, &metastorepb.GetOrCreateStacktracesRequest{
+		Stacktraces: []*metastorepb.Stacktrace{{LocationIds: []string{lres.Locations[0].Id, lres.Locations[1].Id, lres.Locations[2].Id}}},
+	})
+	require.NoError(t, err)
+
+	values := map[string]int64{}
+	err = api.table.View(func(tx uint64) error {
+		return api.table.Iterator(
+			ctx,
+			tx,
+			memory.DefaultAllocator,
+			nil,
+			[]logicalplan.ColumnMatcher{
+				logicalplan.Col(parcacol.ColumnStacktrace).Matcher(),
+				logicalplan.Col(parcacol.ColumnValue).Matcher(),
+			},
+			nil,
+			nil,
+			func(ar arrow.Record) error {
+				stacktraces := ar.Column(0).(*array.Binary)
+				vals := ar.Column(1).(*array.Int64)
+				for i := 0; i < vals.Len(); i++ {
+					values[stacktraces.ValueString(i)] = vals.Value(i)
+				}
+				return nil
+			},
+		)
+	})
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	require.Equal(t, int64(5), values[sres.Stacktraces[0].Id])
+}
+
+func TestWriteJFR(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newTestProfileColumnStore(t, 1, 0)
+	h := NewJFRHandler(api.logger, api)
+
+	stack := []jfr.Frame{
+		{Function: "com.example.Worker.work", Line: 42},
+		{Function: "java.lang.Thread.run", Line: 833},
+	}
+	recording := jfrtest.NewRecording(time.Now(), 10*time.Second, "10 ms", []jfrtest.Event{
+		{Type: jfrtest.ExecutionSample, Stack: stack},
+		{Type: jfrtest.ExecutionSample, Stack: stack},
+		{Type: jfrtest.ExecutionSample, Stack: stack[1:]},
+		{Type: jfrtest.AllocationSample, Stack: stack, Value: 1024},
+		{Type: jfrtest.AllocationSample, Stack: stack, Value: 512},
+		{Type: jfrtest.MonitorEnter, Stack: stack[1:], Value: int64(time.Millisecond)},
+	})
+
+	for _, tc := range []struct {
+		query string
+		body  []byte
+		code  int
+	}{
+		{"label=job=api", []byte("not a recording"), http.StatusBadRequest},
+		{"label=job", recording, http.StatusBadRequest},
+		{"label=__name__=api", recording, http.StatusBadRequest},
+		{"label=job=api", make([]byte, maxBodySize+1), http.StatusRequestEntityTooLarge},
+		{"label=job=api", recording, http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodPost, JFRPath+"?"+tc.query, bytes.NewReader(tc.body))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, tc.code, w.Code, w.Body.String())
+	}
+
+	values := map[string]int64{}
+	samples := map[string]int{}
+	err := api.table.View(func(tx uint64) error {
+		return api.table.Iterator(
+			ctx,
+			tx,
+			memory.DefaultAllocator,
+			nil,
+			[]logicalplan.ColumnMatcher{
+				logicalplan.Col(parcacol.ColumnName).Matcher(),
+				logicalplan.Col(parcacol.ColumnValue).Matcher(),
+			},
+			nil,
+			nil,
+			func(ar arrow.Record) error {
+				names := ar.Column(0).(*array.Binary)
+				vals := ar.Column(1).(*array.Int64)
+				for i := 0; i < vals.Len(); i++ {
+					values[names.ValueString(i)] += vals.Value(i)
+					samples[names.ValueString(i)]++
+				}
+				return nil
+			},
+		)
+	})
+	require.NoError(t, err)
+
+	// Samples of the same stack trace are merged.
+	require.Equal(t, map[string]int64{
+		"jfr_cpu":   3,
+		"jfr_alloc": 1536,
+		"jfr_lock":  int64(time.Millisecond),
+	}, values)
+	require.Equal(t, map[string]int{
+		"jfr_cpu":   2,
+		"jfr_alloc": 1,
+		"jfr_lock":  1,
+	}, samples)
+}
+
+func TestCheckpointWAL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	bucket := objstore.NewInMemBucket()
+	colDB, err := frostdb.New(reg, 8196, 64*1024*1024).WithStorageBucket(bucket).DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table("stacktraces", frostdb.NewTableConfig(parcacol.Schema()), logger)
+	require.NoError(t, err)
+	wal, err := OpenWAL(t.TempDir())
+	require.NoError(t, err)
+	defer wal.Close()
+
+	api := NewProfileColumnStore(
+		logger,
+		tracer,
+		metastore.NewInProcessClient(metastoretest.NewTestMetastore(t, logger, reg, tracer)),
+		table,
+		false,
+		wal,
+		1,
+		0,
+	)
+
+	write := func(value string) {
+		req := testWriteStreamRequest(t, "job")
+		req.Series.Labels.Labels[1].Value = value
+		_, err := api.WriteRaw(ctx, &profilestorepb.WriteRawRequest{
+			Series: []*profilestorepb.RawProfileSeries{req.Series},
+		})
+		require.NoError(t, err)
+	}
+	logged := func() []string {
+		values := []string{}
+		require.NoError(t, wal.Replay(func(req *profilestorepb.WriteRawRequest) error {
+			values = append(values, req.Series[0].Labels.Labels[1].Value)
+			return nil
+		}))
+		return values
+	}
+
+	write("a")
+	require.Equal(t, []string{"a"}, logged())
+
+	// The active block is persisted, so its requests are no longer needed.
+	require.NoError(t, api.CheckpointWAL())
+	require.Equal(t, []string{}, logged())
+	require.Len(t, bucket.Objects(), 1)
+
+	write("b")
+	require.Equal(t, []string{"b"}, logged())
+
+	// Once the columnstore rotated the block, it is persisted again.
+	require.NoError(t, table.RotateBlock())
+	write("c")
+	require.NoError(t, api.CheckpointWAL())
+	require.Equal(t, []string{}, logged())
+	require.Len(t, bucket.Objects(), 2)
+}
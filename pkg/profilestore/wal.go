@@ -0,0 +1,216 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+)
+
+const (
+	walFileName = "wal"
+
+	// walHeaderSize is the size of the header preceding every record, it
+	// holds the length and the checksum of the record.
+	walHeaderSize = 8
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// WAL is a write-ahead log of the write requests accepted by the profile
+// store. Every record is synced to disk before the request is acknowledged,
+// so that profiles that were not yet persisted by the columnstore can be
+// replayed after a crash.
+type WAL struct {
+	mtx  sync.Mutex
+	path string
+	file *os.File
+}
+
+// OpenWAL opens the write-ahead log in the given directory, creating it if
+// it does not exist yet.
+func OpenWAL(dir string) (*WAL, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create wal directory: %w", err)
+	}
+
+	path := filepath.Join(dir, walFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open wal: %w", err)
+	}
+
+	return &WAL{path: path, file: f}, nil
+}
+
+// Log appends the request to the log and syncs it to disk.
+func (w *WAL) Log(req *profilestorepb.WriteRawRequest) error {
+	data, err := req.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	record := make([]byte, walHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(data, castagnoliTable))
+	copy(record[walHeaderSize:], data)
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if _, err := w.file.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if _, err := w.file.Write(record); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+
+	return w.file.Sync()
+}
+
+// Replay calls fn for every request in the log, in the order they were
+// logged. A torn or corrupted record at the end of the log, as left behind by
+// a crash during a write, ends the replay and is cut off the log.
+func (w *WAL) Replay(fn func(*profilestorepb.WriteRawRequest) error) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(w.file)
+	header := make([]byte, walHeaderSize)
+	offset := int64(0)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return fmt.Errorf("read record header: %w", err)
+		}
+
+		data := make([]byte, binary.BigEndian.Uint32(header[0:4]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return fmt.Errorf("read record: %w", err)
+		}
+
+		if crc32.Checksum(data, castagnoliTable) != binary.BigEndian.Uint32(header[4:8]) {
+			break
+		}
+
+		req := &profilestorepb.WriteRawRequest{}
+		if err := req.UnmarshalVT(data); err != nil {
+			return fmt.Errorf("unmarshal record: %w", err)
+		}
+
+		if err := fn(req); err != nil {
+			return err
+		}
+
+		offset += int64(walHeaderSize + len(data))
+	}
+
+	return w.file.Truncate(offset)
+}
+
+// Truncate removes all records from the log. It is to be called once the
+// logged requests have been durably persisted.
+func (w *WAL) Truncate() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+
+	return w.file.Sync()
+}
+
+// Size returns the size of the log, which is the offset the next record is
+// appended at.
+func (w *WAL) Size() (int64, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	fi, err := w.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return fi.Size(), nil
+}
+
+// TruncateBefore removes the records before the offset, as returned by Size,
+// from the log. It is to be called once the requests logged before the offset
+// have been durably persisted. The remaining records are written to a new log
+// that replaces the current one, so that a crash leaves either of them.
+func (w *WAL) TruncateBefore(offset int64) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if _, err := w.file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	tmpPath := w.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("create wal: %w", err)
+	}
+	if _, err := io.Copy(tmp, w.file); err != nil {
+		tmp.Close()
+		return fmt.Errorf("copy records: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		return fmt.Errorf("replace wal: %w", err)
+	}
+
+	f, err := os.OpenFile(w.path, os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("open wal: %w", err)
+	}
+	w.file.Close()
+	w.file = f
+
+	return nil
+}
+
+// Close closes the log.
+func (w *WAL) Close() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.file.Close()
+}
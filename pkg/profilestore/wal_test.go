@@ -0,0 +1,104 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profilestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
+)
+
+func walRequest(value string) *profilestorepb.WriteRawRequest {
+	return &profilestorepb.WriteRawRequest{
+		Series: []*profilestorepb.RawProfileSeries{{
+			Labels: &profilestorepb.LabelSet{
+				Labels: []*profilestorepb.Label{{
+					Name:  "job",
+					Value: value,
+				}},
+			},
+		}},
+	}
+}
+
+func replayedValues(t *testing.T, wal *WAL) []string {
+	values := []string{}
+	err := wal.Replay(func(req *profilestorepb.WriteRawRequest) error {
+		values = append(values, req.Series[0].Labels.Labels[0].Value)
+		return nil
+	})
+	require.NoError(t, err)
+	return values
+}
+
+func TestWAL(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, wal.Log(walRequest("a")))
+	require.NoError(t, wal.Log(walRequest("b")))
+	require.NoError(t, wal.Close())
+
+	// Simulate a crash in the middle of writing a record.
+	f, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 42, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	defer wal.Close()
+
+	require.Equal(t, []string{"a", "b"}, replayedValues(t, wal))
+
+	// The torn record was cut off, so new records are readable again.
+	require.NoError(t, wal.Log(walRequest("c")))
+	require.Equal(t, []string{"a", "b", "c"}, replayedValues(t, wal))
+
+	require.NoError(t, wal.Truncate())
+	require.Equal(t, []string{}, replayedValues(t, wal))
+}
+
+func TestWAL_TruncateBefore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, wal.Log(walRequest("a")))
+	offset, err := wal.Size()
+	require.NoError(t, err)
+	require.NoError(t, wal.Log(walRequest("b")))
+
+	require.NoError(t, wal.TruncateBefore(offset))
+	require.Equal(t, []string{"b"}, replayedValues(t, wal))
+
+	// Records are appended to the new log, and survive reopening it.
+	require.NoError(t, wal.Log(walRequest("c")))
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	defer wal.Close()
+	require.Equal(t, []string{"b", "c"}, replayedValues(t, wal))
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+// GenerateCallgraph generates a callgraph of the profile. Each node is a
+// function, or an address if a location is not symbolized, and each edge a
+// call from one function to another. Inlined functions are nodes of their own.
+// Values of recursive calls are only counted once per sample.
+func GenerateCallgraph(ctx context.Context, p *parcaprofile.Profile) (*pb.Callgraph, error) {
+	nodes := map[string]*pb.CallgraphNode{}
+	edges := map[string]*pb.CallgraphEdge{}
+	keys := []string{}
+	var cumulative int64
+
+	for _, s := range p.Samples {
+		cumulative += s.Value
+
+		// The frames of the stack from the leaf to the root.
+		keys = keys[:0]
+		for _, location := range s.Locations {
+			if len(location.Lines) == 0 {
+				key := fmt.Sprintf("address/%s/%#x", location.Mapping.GetId(), location.Address)
+				if _, ok := nodes[key]; !ok {
+					nodes[key] = &pb.CallgraphNode{Meta: callgraphNodeMeta(location, nil)}
+				}
+				keys = append(keys, key)
+				continue
+			}
+
+			// Like the locations of the stack, inlined lines are ordered from
+			// the innermost to the outermost.
+			for i := range location.Lines {
+				line := location.Lines[i]
+				key := "function/" + line.Function.GetName()
+				if node, ok := nodes[key]; !ok {
+					nodes[key] = &pb.CallgraphNode{Meta: callgraphNodeMeta(location, &line)}
+				} else if node.Meta.Location.Id != location.ID {
+					// The function is found at different locations, so no
+					// single line describes it.
+					node.Meta.Line = nil
+					if node.Meta.Mapping != nil && location.Mapping != nil && node.Meta.Mapping.Id != location.Mapping.Id {
+						node.Meta.Mapping = &metastorev1alpha1.Mapping{}
+					}
+				}
+				keys = append(keys, key)
+			}
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		leaf := nodes[keys[0]]
+		leaf.Flat += s.Value
+		leaf.FlatDiff += s.DiffValue
+
+		seenNodes := make(map[string]struct{}, len(keys))
+		seenEdges := make(map[string]struct{}, len(keys))
+		for i, key := range keys {
+			if _, ok := seenNodes[key]; !ok {
+				seenNodes[key] = struct{}{}
+				node := nodes[key]
+				node.Cumulative += s.Value
+				node.Diff += s.DiffValue
+			}
+
+			if i == len(keys)-1 {
+				continue
+			}
+
+			// The next frame is the caller of this one.
+			edgeKey := keys[i+1] + "\x00" + key
+			if _, ok := seenEdges[edgeKey]; ok {
+				continue
+			}
+			seenEdges[edgeKey] = struct{}{}
+
+			edge, ok := edges[edgeKey]
+			if !ok {
+				edge = &pb.CallgraphEdge{Source: keys[i+1], Target: key}
+				edges[edgeKey] = edge
+			}
+			edge.Cumulative += s.Value
+			edge.Diff += s.DiffValue
+		}
+	}
+
+	return callgraphFromMaps(nodes, edges, cumulative, p.Meta.SampleType.Unit), nil
+}
+
+// callgraphFromMaps returns the callgraph of the nodes and edges, keyed by the
+// keys of their functions. Nodes are sorted by their cumulative value and get
+// IDs in that order, edges are sorted by their cumulative value as well.
+func callgraphFromMaps(
+	nodes map[string]*pb.CallgraphNode,
+	edges map[string]*pb.CallgraphEdge,
+	cumulative int64,
+	unit string,
+) *pb.Callgraph {
+	nodeKeys := make([]string, 0, len(nodes))
+	for key := range nodes {
+		nodeKeys = append(nodeKeys, key)
+	}
+	sort.Slice(nodeKeys, func(i, j int) bool {
+		a, b := nodes[nodeKeys[i]], nodes[nodeKeys[j]]
+		if a.Cumulative == b.Cumulative {
+			return nodeKeys[i] < nodeKeys[j]
+		}
+		return a.Cumulative > b.Cumulative
+	})
+
+	cg := &pb.Callgraph{
+		Nodes:      make([]*pb.CallgraphNode, 0, len(nodes)),
+		Edges:      make([]*pb.CallgraphEdge, 0, len(edges)),
+		Cumulative: cumulative,
+		Unit:       unit,
+	}
+
+	ids := make(map[string]string, len(nodes))
+	for i, key := range nodeKeys {
+		node := nodes[key]
+		node.Id = strconv.Itoa(i)
+		ids[key] = node.Id
+		cg.Nodes = append(cg.Nodes, node)
+	}
+
+	for _, edge := range edges {
+		edge.Source = ids[edge.Source]
+		edge.Target = ids[edge.Target]
+		edge.Id = edge.Source + "->" + edge.Target
+		cg.Edges = append(cg.Edges, edge)
+	}
+	sort.Slice(cg.Edges, func(i, j int) bool {
+		if cg.Edges[i].Cumulative == cg.Edges[j].Cumulative {
+			return cg.Edges[i].Id < cg.Edges[j].Id
+		}
+		return cg.Edges[i].Cumulative > cg.Edges[j].Cumulative
+	})
+
+	return cg
+}
+
+func callgraphNodeMeta(location *parcaprofile.Location, line *parcaprofile.LocationLine) *pb.CallgraphNodeMeta {
+	meta := &pb.CallgraphNodeMeta{
+		Mapping: location.Mapping,
+		Location: &metastorev1alpha1.Location{
+			Id:        location.ID,
+			Address:   location.Address,
+			MappingId: location.Mapping.GetId(),
+			IsFolded:  location.IsFolded,
+		},
+	}
+	if line != nil {
+		meta.Function = line.Function
+		meta.Line = &metastorev1alpha1.Line{
+			FunctionId: line.Function.GetId(),
+			Line:       line.Line,
+		}
+	}
+
+	return meta
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateCallgraph(t *testing.T) {
+	t.Parallel()
+
+	mapping := &metastorev1alpha1.Mapping{Id: "m1", File: "a.out"}
+	newLocation := func(id string, name string) *profile.Location {
+		return &profile.Location{
+			ID:      id,
+			Mapping: mapping,
+			Lines: []profile.LocationLine{{
+				Line:     1,
+				Function: &metastorev1alpha1.Function{Id: "f" + id, Name: name},
+			}},
+		}
+	}
+
+	main := newLocation("1", "main")
+	foo := newLocation("2", "foo")
+	bar := newLocation("3", "bar")
+	unsymbolized := &profile.Location{ID: "4", Address: 0xa, Mapping: mapping}
+
+	p := &profile.Profile{
+		Meta: profile.Meta{SampleType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"}},
+		Samples: []*profile.SymbolizedSample{{
+			// main -> foo -> foo -> bar, the recursion is only counted once.
+			Locations: []*profile.Location{bar, foo, foo, main},
+			Value:     3,
+			DiffValue: 1,
+		}, {
+			Locations: []*profile.Location{foo, main},
+			Value:     2,
+		}, {
+			Locations: []*profile.Location{unsymbolized, main},
+			Value:     1,
+			DiffValue: -1,
+		}},
+	}
+
+	cg, err := GenerateCallgraph(context.Background(), p)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(6), cg.Cumulative)
+	require.Equal(t, "nanoseconds", cg.Unit)
+
+	type node struct {
+		name       string
+		cumulative int64
+		flat       int64
+		diff       int64
+		flatDiff   int64
+	}
+	nodes := make([]node, 0, len(cg.Nodes))
+	names := map[string]string{}
+	for _, n := range cg.Nodes {
+		name := n.Meta.GetFunction().GetName()
+		if name == "" {
+			require.Equal(t, uint64(0xa), n.Meta.Location.Address)
+			name = "0xa"
+		}
+		names[n.Id] = name
+		nodes = append(nodes, node{
+			name:       name,
+			cumulative: n.Cumulative,
+			flat:       n.Flat,
+			diff:       n.Diff,
+			flatDiff:   n.FlatDiff,
+		})
+	}
+	require.Equal(t, []node{
+		{name: "main", cumulative: 6, flat: 0, diff: 0, flatDiff: 0},
+		{name: "foo", cumulative: 5, flat: 2, diff: 1, flatDiff: 0},
+		{name: "bar", cumulative: 3, flat: 3, diff: 1, flatDiff: 1},
+		{name: "0xa", cumulative: 1, flat: 1, diff: -1, flatDiff: -1},
+	}, nodes)
+
+	type edge struct {
+		source     string
+		target     string
+		cumulative int64
+		diff       int64
+	}
+	edges := make([]edge, 0, len(cg.Edges))
+	for _, e := range cg.Edges {
+		require.Equal(t, e.Source+"->"+e.Target, e.Id)
+		edges = append(edges, edge{
+			source:     names[e.Source],
+			target:     names[e.Target],
+			cumulative: e.Cumulative,
+			diff:       e.Diff,
+		})
+	}
+	require.Equal(t, []edge{
+		{source: "main", target: "foo", cumulative: 5, diff: 1},
+		{source: "foo", target: "foo", cumulative: 3, diff: 1},
+		{source: "foo", target: "bar", cumulative: 3, diff: 1},
+		{source: "main", target: "0xa", cumulative: 1, diff: -1},
+	}, edges)
+}
+
+func TestGenerateCallgraphInlined(t *testing.T) {
+	t.Parallel()
+
+	p := &profile.Profile{
+		Samples: []*profile.SymbolizedSample{{
+			Locations: []*profile.Location{{
+				ID: "1",
+				Lines: []profile.LocationLine{
+					{Line: 10, Function: &metastorev1alpha1.Function{Id: "2", Name: "inlined"}},
+					{Line: 20, Function: &metastorev1alpha1.Function{Id: "1", Name: "caller"}},
+				},
+			}},
+			Value: 1,
+		}},
+	}
+
+	cg, err := GenerateCallgraph(context.Background(), p)
+	require.NoError(t, err)
+	require.Len(t, cg.Nodes, 2)
+	require.Len(t, cg.Edges, 1)
+
+	ids := map[string]*pb.CallgraphNode{}
+	for _, n := range cg.Nodes {
+		ids[n.Meta.Function.Name] = n
+	}
+	require.Equal(t, int64(1), ids["inlined"].Flat)
+	require.Equal(t, int64(0), ids["caller"].Flat)
+	require.Equal(t, int64(10), ids["inlined"].Meta.Line.Line)
+	require.Equal(t, ids["caller"].Id, cg.Edges[0].Source)
+	require.Equal(t, ids["inlined"].Id, cg.Edges[0].Target)
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/polarsignals/frostdb/query"
 	"github.com/polarsignals/frostdb/query/logicalplan"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/promql/parser"
@@ -42,11 +44,12 @@ import (
 	sharepb "github.com/parca-dev/parca/gen/proto/go/share"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/symbolizer"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type Engine interface {
 	ScanTable(name string) query.Builder
-	ScanSchema(name string) query.Builder
 }
 
 // ColumnQueryAPI is the read api interface for parca
@@ -60,6 +63,7 @@ type ColumnQueryAPI struct {
 	tableName   string
 	metastore   metastorepb.MetastoreServiceClient
 	shareClient sharepb.ShareClient
+	debuginfo   symbolizer.DebugInfoFetcher
 }
 
 func NewColumnQueryAPI(
@@ -67,6 +71,7 @@ func NewColumnQueryAPI(
 	tracer trace.Tracer,
 	metastore metastorepb.MetastoreServiceClient,
 	shareClient sharepb.ShareClient,
+	debuginfo symbolizer.DebugInfoFetcher,
 	engine Engine,
 	tableName string,
 ) *ColumnQueryAPI {
@@ -77,38 +82,117 @@ func NewColumnQueryAPI(
 		tableName:   tableName,
 		metastore:   metastore,
 		shareClient: shareClient,
+		debuginfo:   debuginfo,
 	}
 }
 
+// maxLabelResults is the maximum number of label names or values returned,
+// further results are truncated.
+const maxLabelResults = 10000
+
 // Labels issues a labels request against the storage.
 func (q *ColumnQueryAPI) Labels(ctx context.Context, req *pb.LabelsRequest) (*pb.LabelsResponse, error) {
+	filterExprs, err := matchesToFilterExprs(ctx, req.Match, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
 	seen := map[string]struct{}{}
+	for _, filterExpr := range filterExprs {
+		// The label columns are read from the distinct label sets of the
+		// matching samples rather than the schema of the table, which
+		// contains the labels of all samples, including those of other
+		// tenants. Records may contain label columns of other label sets that
+		// are all null.
+		err := q.engine.ScanTable(q.tableName).
+			Filter(filterExpr).
+			Distinct(logicalplan.DynCol(parcacol.ColumnLabels)).
+			Execute(ctx, func(ar arrow.Record) error {
+				for i, field := range ar.Schema().Fields() {
+					if !strings.HasPrefix(field.Name, "labels.") {
+						continue
+					}
+
+					col := ar.Column(i)
+					if col.NullN() == col.Len() {
+						continue
+					}
+
+					seen[strings.TrimPrefix(field.Name, "labels.")] = struct{}{}
+				}
 
-	err := q.engine.ScanSchema(q.tableName).
-		Distinct(logicalplan.Col("name")).
-		Filter(logicalplan.Col("name").RegexMatch("^labels\\..+$")).
-		Execute(ctx, func(ar arrow.Record) error {
-			if ar.NumCols() != 1 {
-				return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
-			}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			col := ar.Column(0)
-			stringCol, ok := col.(*array.String)
-			if !ok {
-				return fmt.Errorf("expected string column, got %T", col)
-			}
+	vals, warnings := sortedLabelResults(seen, "label names")
 
-			for i := 0; i < stringCol.Len(); i++ {
-				val := stringCol.Value(i)
-				seen[strings.TrimPrefix(val, "labels.")] = struct{}{}
-			}
+	return &pb.LabelsResponse{
+		LabelNames: vals,
+		Warnings:   warnings,
+	}, nil
+}
 
-			return nil
-		})
+// Values issues a values request against the storage.
+func (q *ColumnQueryAPI) Values(ctx context.Context, req *pb.ValuesRequest) (*pb.ValuesResponse, error) {
+	name := req.LabelName
+	if !model.LabelName(name).IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid label name: %q", name)
+	}
+
+	filterExprs, err := matchesToFilterExprs(ctx, req.Match, req.Start, req.End)
 	if err != nil {
 		return nil, err
 	}
 
+	seen := map[string]struct{}{}
+	for _, filterExpr := range filterExprs {
+		err := q.engine.ScanTable(q.tableName).
+			Filter(filterExpr).
+			Distinct(logicalplan.Col("labels."+name)).
+			Execute(ctx, func(ar arrow.Record) error {
+				if ar.NumCols() != 1 {
+					return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
+				}
+
+				col := ar.Column(0)
+				stringCol, ok := col.(*array.Binary)
+				if !ok {
+					return fmt.Errorf("expected string column, got %T", col)
+				}
+
+				for i := 0; i < stringCol.Len(); i++ {
+					if stringCol.IsNull(i) {
+						continue
+					}
+
+					val := stringCol.Value(i)
+					if len(val) > 0 {
+						seen[string(val)] = struct{}{}
+					}
+				}
+
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vals, warnings := sortedLabelResults(seen, "label values")
+
+	return &pb.ValuesResponse{
+		LabelValues: vals,
+		Warnings:    warnings,
+	}, nil
+}
+
+// sortedLabelResults returns the sorted results, truncated to
+// maxLabelResults. If they were truncated, a warning saying so is returned.
+func sortedLabelResults(seen map[string]struct{}, kind string) ([]string, []string) {
 	vals := make([]string, 0, len(seen))
 	for val := range seen {
 		vals = append(vals, val)
@@ -116,49 +200,92 @@ func (q *ColumnQueryAPI) Labels(ctx context.Context, req *pb.LabelsRequest) (*pb
 
 	sort.Strings(vals)
 
-	return &pb.LabelsResponse{
-		LabelNames: vals,
-	}, nil
+	if len(vals) <= maxLabelResults {
+		return vals, nil
+	}
+
+	return vals[:maxLabelResults], []string{
+		fmt.Sprintf("results truncated to %d of %d %s, use more specific matchers or a smaller time range", maxLabelResults, len(vals), kind),
+	}
 }
 
-// Values issues a values request against the storage.
-func (q *ColumnQueryAPI) Values(ctx context.Context, req *pb.ValuesRequest) (*pb.ValuesResponse, error) {
-	name := req.LabelName
-	vals := []string{}
+// matchesToFilterExprs returns a filter expression for each of the selectors,
+// restricted to the time range if it is given. A sample is selected if it
+// matches any of the returned filters. Without selectors all samples of the
+// tenant are selected.
+func matchesToFilterExprs(ctx context.Context, match []string, start, end *timestamppb.Timestamp) ([]logicalplan.Expr, error) {
+	timeExprs := []logicalplan.Expr{}
+	if start != nil {
+		timeExprs = append(timeExprs, logicalplan.Col(parcacol.ColumnTimestamp).GT(logicalplan.Literal(timestamp.FromTime(start.AsTime()))))
+	}
+	if end != nil {
+		timeExprs = append(timeExprs, logicalplan.Col(parcacol.ColumnTimestamp).LT(logicalplan.Literal(timestamp.FromTime(end.AsTime()))))
+	}
 
-	err := q.engine.ScanTable(q.tableName).
-		Distinct(logicalplan.Col("labels."+name)).
-		Execute(ctx, func(ar arrow.Record) error {
-			if ar.NumCols() != 1 {
-				return fmt.Errorf("expected 1 column, got %d", ar.NumCols())
-			}
+	if len(match) == 0 {
+		return []logicalplan.Expr{
+			logicalplan.And(append([]logicalplan.Expr{tenantFilter(ctx)}, timeExprs...)...),
+		}, nil
+	}
 
-			col := ar.Column(0)
-			stringCol, ok := col.(*array.Binary)
-			if !ok {
-				return fmt.Errorf("expected string column, got %T", col)
-			}
+	filterExprs := make([]logicalplan.Expr, 0, len(match))
+	for _, m := range match {
+		exprs, err := matchToFilterExprs(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		filterExprs = append(filterExprs, logicalplan.And(append(exprs, timeExprs...)...))
+	}
 
-			for i := 0; i < stringCol.Len(); i++ {
-				val := stringCol.Value(i)
-				vals = append(vals, string(val))
-			}
+	return filterExprs, nil
+}
 
-			return nil
-		})
+// matchToFilterExprs returns the filter expressions of a selector. Unlike
+// queries, the selector may omit the profile type to select the samples of
+// all profile types.
+func matchToFilterExprs(ctx context.Context, match string) ([]logicalplan.Expr, error) {
+	parsedSelector, err := parser.ParseMetricSelector(match)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, "failed to parse selector")
 	}
 
-	sort.Strings(vals)
+	for _, matcher := range parsedSelector {
+		if matcher.Name == labels.MetricName {
+			_, exprs, err := queryToFilterExprs(ctx, match)
+			return exprs, err
+		}
+	}
 
-	return &pb.ValuesResponse{
-		LabelValues: vals,
-	}, nil
+	labelFilterExpressions, err := matchersToBooleanExpressions(parsedSelector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to build query: %v", err)
+	}
+
+	return append([]logicalplan.Expr{tenantFilter(ctx)}, labelFilterExpressions...), nil
 }
 
+const (
+	// pprofLabelPrefix is the prefix of label names in selectors that match
+	// on the pprof labels of samples rather than the labels of series, e.g.
+	// pprof_endpoint matches on the pprof label endpoint.
+	pprofLabelPrefix = "pprof_"
+	// pprofNumLabelPrefix is the prefix of label names in selectors that match
+	// on the numeric pprof labels of samples. It takes precedence over
+	// pprofLabelPrefix, so pprof labels whose name starts with num_ cannot be
+	// matched on.
+	pprofNumLabelPrefix = "pprof_num_"
+)
+
 func matcherToBooleanExpression(matcher *labels.Matcher) (logicalplan.Expr, error) {
+	if strings.HasPrefix(matcher.Name, pprofNumLabelPrefix) {
+		return numMatcherToBooleanExpression(matcher)
+	}
+
 	ref := logicalplan.Col("labels." + matcher.Name)
+	if strings.HasPrefix(matcher.Name, pprofLabelPrefix) {
+		ref = logicalplan.Col(parcacol.ColumnPprofLabels + "." + strings.TrimPrefix(matcher.Name, pprofLabelPrefix))
+	}
+
 	switch matcher.Type {
 	case labels.MatchEqual:
 		return ref.Eq(logicalplan.Literal(matcher.Value)), nil
@@ -173,6 +300,26 @@ func matcherToBooleanExpression(matcher *labels.Matcher) (logicalplan.Expr, erro
 	}
 }
 
+// numMatcherToBooleanExpression returns the expression of a matcher on a
+// numeric pprof label, whose value must be an integer.
+func numMatcherToBooleanExpression(matcher *labels.Matcher) (logicalplan.Expr, error) {
+	ref := logicalplan.Col(parcacol.ColumnPprofNumLabels + "." + strings.TrimPrefix(matcher.Name, pprofNumLabelPrefix))
+
+	v, err := strconv.ParseInt(matcher.Value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of numeric pprof label %q: %w", matcher.Name, err)
+	}
+
+	switch matcher.Type {
+	case labels.MatchEqual:
+		return ref.Eq(logicalplan.Literal(v)), nil
+	case labels.MatchNotEqual:
+		return ref.NotEq(logicalplan.Literal(v)), nil
+	default:
+		return nil, fmt.Errorf("unsupported matcher type %v for numeric pprof label %q", matcher.Type.String(), matcher.Name)
+	}
+}
+
 func matchersToBooleanExpressions(matchers []*labels.Matcher) ([]logicalplan.Expr, error) {
 	exprs := make([]logicalplan.Expr, 0, len(matchers))
 
@@ -193,7 +340,13 @@ var (
 	ErrValueColumnNotFound     = errors.New("value column not found")
 )
 
-func queryToFilterExprs(query string) (profile.Meta, []logicalplan.Expr, error) {
+// tenantFilter returns the expression selecting the samples of the tenant of
+// the context. It must be part of every query to isolate the tenants.
+func tenantFilter(ctx context.Context) logicalplan.Expr {
+	return logicalplan.Col(parcacol.ColumnTenant).Eq(logicalplan.Literal(tenant.FromContext(ctx)))
+}
+
+func queryToFilterExprs(ctx context.Context, query string) (profile.Meta, []logicalplan.Expr, error) {
 	parsedSelector, err := parser.ParseMetricSelector(query)
 	if err != nil {
 		return profile.Meta{}, nil, status.Error(codes.InvalidArgument, "failed to parse query")
@@ -223,10 +376,11 @@ func queryToFilterExprs(query string) (profile.Meta, []logicalplan.Expr, error)
 
 	labelFilterExpressions, err := matchersToBooleanExpressions(sel)
 	if err != nil {
-		return profile.Meta{}, nil, status.Error(codes.InvalidArgument, "failed to build query")
+		return profile.Meta{}, nil, status.Errorf(codes.InvalidArgument, "failed to build query: %v", err)
 	}
 
 	exprs := append([]logicalplan.Expr{
+		tenantFilter(ctx),
 		logicalplan.Col("name").Eq(logicalplan.Literal(name)),
 		logicalplan.Col("sample_type").Eq(logicalplan.Literal(sampleType)),
 		logicalplan.Col("sample_unit").Eq(logicalplan.Literal(sampleUnit)),
@@ -254,7 +408,7 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	_, selectorExprs, err := queryToFilterExprs(req.Query)
+	_, selectorExprs, err := queryToFilterExprs(ctx, req.Query)
 	if err != nil {
 		return nil, err
 	}
@@ -330,17 +484,49 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 		return nil, ErrValueColumnNotFound
 	}
 
-	for i := 0; i < int(ar.NumRows()); i++ {
+	// Steps are rounded up to full milliseconds. Without a step every
+	// profile is a sample of its series.
+	step := int64((req.Step.AsDuration() + time.Millisecond - 1) / time.Millisecond)
+
+	// The series of all label sets that have the same values for the labels
+	// grouped by are merged into a single series.
+	groupBy := map[string]struct{}{}
+	for _, name := range req.GroupBy {
+		groupBy[name] = struct{}{}
+	}
+
+	timestampColumn, ok := ar.Column(timestampColumnIndex).(*array.Int64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "expected timestamp column to be an int64 column, got %T", ar.Column(timestampColumnIndex))
+	}
+	valueColumn, ok := ar.Column(valueColumnIndex).(*array.Int64)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "expected value column to be an int64 column, got %T", ar.Column(valueColumnIndex))
+	}
+	labelColumns, err := labelColumnsFromRecord(ar, labelColumnIndices)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := int(ar.NumRows())
+	rowSeries := make([]int, rows)
+	stepRows := map[int64][]int{}
+	for i := 0; i < rows; i++ {
 		labelSet = labelSet[:0]
-		for _, labelColumnIndex := range labelColumnIndices {
-			col := ar.Column(labelColumnIndex).(*array.Binary)
+		for j, labelColumnIndex := range labelColumnIndices {
+			col := labelColumns[j]
 			if col.IsNull(i) {
 				continue
 			}
 
+			name := strings.TrimPrefix(fields[labelColumnIndex].Name, "labels.")
+			if _, ok := groupBy[name]; len(groupBy) > 0 && !ok {
+				continue
+			}
+
 			v := col.Value(i)
 			if len(v) > 0 {
-				labelSet = append(labelSet, labels.Label{Name: strings.TrimPrefix(fields[labelColumnIndex].Name, "labels."), Value: string(v)})
+				labelSet = append(labelSet, labels.Label{Name: name, Value: string(v)})
 			}
 		}
 
@@ -360,31 +546,276 @@ func (q *ColumnQueryAPI) QueryRange(ctx context.Context, req *pb.QueryRangeReque
 			labelsetToIndex[s] = index
 		}
 
-		series := res.Series[index]
-		series.Samples = append(series.Samples, &pb.MetricsSample{
-			Timestamp: timestamppb.New(timestamp.Time(ar.Column(timestampColumnIndex).(*array.Int64).Value(i))),
-			Value:     ar.Column(valueColumnIndex).(*array.Int64).Value(i),
-		})
+		rowSeries[i] = index
+		st := timestampColumn.Value(i)
+		if step > 0 {
+			st = (st - start) / step
+		}
+		stepRows[st] = append(stepRows[st], i)
 	}
 
-	// This is horrible and should be fixed. The data is sorted in the storage, we should not have to sort it here.
-	for _, series := range res.Series {
-		sort.Slice(series.Samples, func(i, j int) bool {
-			return series.Samples[i].Timestamp.AsTime().Before(series.Samples[j].Timestamp.AsTime())
-		})
+	// The aggregated rows are not ordered by their timestamp. Rather than
+	// sorting the samples of each series, the rows are visited in order of
+	// their steps, so that samples are appended in order of time.
+	steps := make([]int64, 0, len(stepRows))
+	for st := range stepRows {
+		steps = append(steps, st)
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i] < steps[j]
+	})
+
+	// The values of the profiles of a series within the same step are
+	// aggregated into a single sample. The timestamp of each sample is the
+	// timestamp of the earliest profile within its step, so that the profile
+	// can be selected by it.
+	lastSteps := make([]int64, len(res.Series))
+	lastTimestamps := make([]int64, len(res.Series))
+	lastCounts := make([]int64, len(res.Series))
+	for i := range lastSteps {
+		lastSteps[i] = -1
+	}
+	for _, st := range steps {
+		for _, i := range stepRows[st] {
+			index := rowSeries[i]
+			series := res.Series[index]
+			ts := timestampColumn.Value(i)
+			value := valueColumn.Value(i)
+			if lastSteps[index] != st {
+				finishSample(series, lastCounts[index], req.Aggregation)
+				lastSteps[index] = st
+				lastTimestamps[index] = ts
+				lastCounts[index] = 1
+				series.Samples = append(series.Samples, &pb.MetricsSample{
+					Timestamp: timestamppb.New(timestamp.Time(ts)),
+					Value:     value,
+				})
+				continue
+			}
+
+			sample := series.Samples[len(series.Samples)-1]
+			lastCounts[index]++
+			switch req.Aggregation {
+			case pb.QueryRangeRequest_AGGREGATION_MAX:
+				if value > sample.Value {
+					sample.Value = value
+				}
+			default:
+				// Averages are summed up until the sample is finished.
+				sample.Value += value
+			}
+			if ts < lastTimestamps[index] {
+				lastTimestamps[index] = ts
+				sample.Timestamp = timestamppb.New(timestamp.Time(ts))
+			}
+		}
+	}
+	for index, series := range res.Series {
+		finishSample(series, lastCounts[index], req.Aggregation)
+	}
+
+	res.Series = limitSeries(res.Series, int(req.Limit))
+
+	return res, nil
+}
+
+// finishSample finishes the aggregation of the last sample of the series from
+// the given number of profile values.
+func finishSample(series *pb.MetricsSeries, count int64, aggregation pb.QueryRangeRequest_Aggregation) {
+	if aggregation != pb.QueryRangeRequest_AGGREGATION_AVG || count == 0 {
+		return
+	}
+
+	sample := series.Samples[len(series.Samples)-1]
+	sample.Value /= count
+}
+
+// limitSeries returns the limit series with the largest total values, in
+// descending order of their totals. A limit of 0 returns all series.
+func limitSeries(series []*pb.MetricsSeries, limit int) []*pb.MetricsSeries {
+	if limit == 0 || len(series) <= limit {
+		return series
+	}
+
+	totals := make(map[*pb.MetricsSeries]int64, len(series))
+	for _, s := range series {
+		total := int64(0)
+		for _, sample := range s.Samples {
+			total += sample.Value
+		}
+		totals[s] = total
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		return totals[series[i]] > totals[series[j]]
+	})
+
+	return series[:limit]
+}
+
+// Series issues a series request against the storage.
+func (q *ColumnQueryAPI) Series(ctx context.Context, req *pb.SeriesRequest) (*pb.SeriesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filterExprs, err := matchesToFilterExprs(ctx, req.Match, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]*pb.Series{}
+	for _, filterExpr := range filterExprs {
+		// The duration is selected rather than whether it is greater than
+		// 0, as filtering drops computed columns from the distinct results.
+		err := q.engine.ScanTable(q.tableName).
+			Filter(filterExpr).
+			Distinct(
+				logicalplan.DynCol(parcacol.ColumnLabels),
+				logicalplan.Col(parcacol.ColumnName),
+				logicalplan.Col(parcacol.ColumnSampleType),
+				logicalplan.Col(parcacol.ColumnSampleUnit),
+				logicalplan.Col(parcacol.ColumnPeriodType),
+				logicalplan.Col(parcacol.ColumnPeriodUnit),
+				logicalplan.Col(parcacol.ColumnDuration),
+			).
+			Execute(ctx, func(ar arrow.Record) error {
+				return seriesFromRecord(ar, seen)
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := &pb.SeriesResponse{
+		Series: make([]*pb.Series, 0, len(keys)),
+	}
+	for _, key := range keys {
+		res.Series = append(res.Series, seen[key])
 	}
 
 	return res, nil
 }
 
+// seriesFromRecord adds the series of the distinct label sets and profile
+// types of the record to the seen series, keyed by their label set and
+// profile type.
+func seriesFromRecord(ar arrow.Record, seen map[string]*pb.Series) error {
+	nameColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnName)
+	if err != nil {
+		return err
+	}
+
+	sampleTypeColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnSampleType)
+	if err != nil {
+		return err
+	}
+
+	sampleUnitColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnSampleUnit)
+	if err != nil {
+		return err
+	}
+
+	periodTypeColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnPeriodType)
+	if err != nil {
+		return err
+	}
+
+	periodUnitColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnPeriodUnit)
+	if err != nil {
+		return err
+	}
+
+	indices := ar.Schema().FieldIndices(parcacol.ColumnDuration)
+	if len(indices) != 1 {
+		return fmt.Errorf("expected 1 column named %q, got %d", parcacol.ColumnDuration, len(indices))
+	}
+	durationColumn, ok := ar.Column(indices[0]).(*array.Int64)
+	if !ok {
+		return fmt.Errorf("expected column %q to be an int64 column, got %T", parcacol.ColumnDuration, ar.Column(indices[0]))
+	}
+
+	fields := ar.Schema().Fields()
+	labelColumnIndices := []int{}
+	for i, field := range fields {
+		if strings.HasPrefix(field.Name, "labels.") {
+			labelColumnIndices = append(labelColumnIndices, i)
+		}
+	}
+
+	labelColumns, err := labelColumnsFromRecord(ar, labelColumnIndices)
+	if err != nil {
+		return err
+	}
+
+	labelSet := labels.Labels{}
+	for i := 0; i < int(ar.NumRows()); i++ {
+		labelSet = labelSet[:0]
+		for j, labelColumnIndex := range labelColumnIndices {
+			col := labelColumns[j]
+			if col.IsNull(i) {
+				continue
+			}
+
+			v := col.Value(i)
+			if len(v) > 0 {
+				labelSet = append(labelSet, labels.Label{Name: strings.TrimPrefix(fields[labelColumnIndex].Name, "labels."), Value: string(v)})
+			}
+		}
+		sort.Sort(labelSet)
+
+		profileType := &pb.ProfileType{
+			Name:       string(nameColumn.Value(i)),
+			SampleType: string(sampleTypeColumn.Value(i)),
+			SampleUnit: string(sampleUnitColumn.Value(i)),
+			PeriodType: string(periodTypeColumn.Value(i)),
+			PeriodUnit: string(periodUnitColumn.Value(i)),
+			Delta:      durationColumn.Value(i) > 0,
+		}
+
+		key := fmt.Sprintf("%s%s:%s:%s:%s:%s", labelSet.String(), profileType.Name, profileType.SampleType, profileType.SampleUnit, profileType.PeriodType, profileType.PeriodUnit)
+		if profileType.Delta {
+			key = fmt.Sprintf("%s:delta", key)
+		}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		pbLabelSet := make([]*profilestorepb.Label, 0, len(labelSet))
+		for _, l := range labelSet {
+			pbLabelSet = append(pbLabelSet, &profilestorepb.Label{
+				Name:  l.Name,
+				Value: l.Value,
+			})
+		}
+		seen[key] = &pb.Series{
+			Labelset:    &profilestorepb.LabelSet{Labels: pbLabelSet},
+			ProfileType: profileType,
+		}
+	}
+
+	return nil
+}
+
 // Types returns the available types of profiles.
 func (q *ColumnQueryAPI) ProfileTypes(ctx context.Context, req *pb.ProfileTypesRequest) (*pb.ProfileTypesResponse, error) {
 	res := &pb.ProfileTypesResponse{}
 
 	seen := map[string]struct{}{}
 
+	// The tenant is part of the distinct columns rather than filtered on, as
+	// filtering drops the computed delta column from the distinct results.
+	t := tenant.FromContext(ctx)
 	err := q.engine.ScanTable(q.tableName).
 		Distinct(
+			logicalplan.Col(parcacol.ColumnTenant),
 			logicalplan.Col(parcacol.ColumnName),
 			logicalplan.Col(parcacol.ColumnSampleType),
 			logicalplan.Col(parcacol.ColumnSampleUnit),
@@ -393,8 +824,13 @@ func (q *ColumnQueryAPI) ProfileTypes(ctx context.Context, req *pb.ProfileTypesR
 			logicalplan.Col(parcacol.ColumnDuration).GT(logicalplan.Literal(0)),
 		).
 		Execute(ctx, func(ar arrow.Record) error {
-			if ar.NumCols() != 6 {
-				return fmt.Errorf("expected 6 column, got %d", ar.NumCols())
+			if ar.NumCols() != 7 {
+				return fmt.Errorf("expected 7 column, got %d", ar.NumCols())
+			}
+
+			tenantColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnTenant)
+			if err != nil {
+				return err
 			}
 
 			nameColumn, err := binaryFieldFromRecord(ar, parcacol.ColumnName)
@@ -428,6 +864,10 @@ func (q *ColumnQueryAPI) ProfileTypes(ctx context.Context, req *pb.ProfileTypesR
 			}
 
 			for i := 0; i < int(ar.NumRows()); i++ {
+				if string(tenantColumn.Value(i)) != t {
+					continue
+				}
+
 				name := string(nameColumn.Value(i))
 				sampleType := string(sampleTypeColumn.Value(i))
 				sampleUnit := string(sampleUnitColumn.Value(i))
@@ -478,6 +918,21 @@ func binaryFieldFromRecord(ar arrow.Record, name string) (*array.Binary, error)
 	return col, nil
 }
 
+// labelColumnsFromRecord returns the label columns at the indices of the
+// record.
+func labelColumnsFromRecord(ar arrow.Record, indices []int) ([]*array.Binary, error) {
+	cols := make([]*array.Binary, 0, len(indices))
+	for _, i := range indices {
+		col, ok := ar.Column(i).(*array.Binary)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "expected label column %q to be a binary column, got %T", ar.Schema().Field(i).Name, ar.Column(i))
+		}
+		cols = append(cols, col)
+	}
+
+	return cols, nil
+}
+
 func booleanFieldFromRecord(ar arrow.Record, name string) (*array.Boolean, error) {
 	indices := ar.Schema().FieldIndices(name)
 	if len(indices) != 1 {
@@ -500,23 +955,34 @@ func (q *ColumnQueryAPI) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Q
 
 	switch req.Mode {
 	case pb.QueryRequest_MODE_SINGLE_UNSPECIFIED:
-		return q.singleRequest(ctx, req.GetSingle(), req.GetReportType())
+		return q.singleRequest(ctx, req)
 	case pb.QueryRequest_MODE_MERGE:
-		return q.mergeRequest(ctx, req.GetMerge(), req.GetReportType())
+		return q.mergeRequest(ctx, req)
 	case pb.QueryRequest_MODE_DIFF:
-		return q.diffRequest(ctx, req.GetDiff(), req.GetReportType())
+		if len(req.GroupBy) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "grouping by labels is not supported for diff queries")
+		}
+		return q.diffRequest(ctx, req)
 	default:
 		return nil, status.Error(codes.InvalidArgument, "unknown query mode")
 	}
 }
 
-func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.Profile, typ pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
-	switch typ {
+func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.Profile, req *pb.QueryRequest) (*pb.QueryResponse, error) {
+	filter, err := NewStackFilter(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	p = FilterProfile(p, filter)
+
+	switch req.GetReportType() {
 	case pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED:
-		fg, err := GenerateFlamegraphFlat(ctx, q.tracer, p)
+		fg, err := GenerateFlamegraphFlat(ctx, q.tracer, p, req.GroupBy)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to generate flamegraph: %v", err.Error())
 		}
+		fg = TrimFlamegraph(fg, req.NodeTrimThreshold, req.MaxDepth, req.CollapseTrimmed)
+
 		return &pb.QueryResponse{
 			Report: &pb.QueryResponse_Flamegraph{
 				Flamegraph: fg,
@@ -545,23 +1011,65 @@ func (q *ColumnQueryAPI) renderReport(ctx context.Context, p *profile.Profile, t
 		return &pb.QueryResponse{
 			Report: &pb.QueryResponse_Top{Top: top},
 		}, nil
+	case pb.QueryRequest_REPORT_TYPE_CALLGRAPH:
+		callgraph, err := GenerateCallgraph(ctx, p)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate callgraph: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Callgraph{Callgraph: callgraph},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_SOURCE:
+		source, err := GenerateSource(ctx, p, req.GetSourceReference())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate source report: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Source{Source: source},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_DISASSEMBLY:
+		if q.debuginfo == nil {
+			return nil, status.Error(codes.FailedPrecondition, "disassembly requires debuginfo, which is not available")
+		}
+
+		disassembly, err := GenerateDisassembly(ctx, q.logger, q.debuginfo, p, req.GetSourceReference())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate disassembly: %v", err.Error())
+		}
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Disassembly{Disassembly: disassembly},
+		}, nil
+	case pb.QueryRequest_REPORT_TYPE_SANDWICH:
+		sandwich, err := GenerateSandwich(ctx, q.tracer, p, req.GetSourceReference())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate sandwich: %v", err.Error())
+		}
+		sandwich.Callers = TrimFlamegraph(sandwich.Callers, req.NodeTrimThreshold, req.MaxDepth, req.CollapseTrimmed)
+		sandwich.Callees = TrimFlamegraph(sandwich.Callees, req.NodeTrimThreshold, req.MaxDepth, req.CollapseTrimmed)
+
+		return &pb.QueryResponse{
+			Report: &pb.QueryResponse_Sandwich{Sandwich: sandwich},
+		}, nil
 	default:
 		return nil, status.Error(codes.InvalidArgument, "requested report type does not exist")
 	}
 }
 
-func (q *ColumnQueryAPI) singleRequest(ctx context.Context, s *pb.SingleProfile, reportType pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
-	p, err := q.selectSingle(ctx, s)
+func (q *ColumnQueryAPI) singleRequest(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
+	p, err := q.selectSingle(ctx, req.GetSingle(), needsPprofLabels(req))
 	if err != nil {
 		return nil, err
 	}
 
-	return q.renderReport(ctx, p, reportType)
+	return q.renderReport(ctx, p, req)
 }
 
-func (q *ColumnQueryAPI) selectSingle(ctx context.Context, s *pb.SingleProfile) (*profile.Profile, error) {
+func (q *ColumnQueryAPI) selectSingle(ctx context.Context, s *pb.SingleProfile, pprofLabels bool) (*profile.Profile, error) {
 	t := s.Time.AsTime()
-	p, err := q.findSingle(ctx, s.Query, t)
+	p, err := q.findSingle(ctx, s.Query, t, pprofLabels)
 	if err != nil {
 		return nil, err
 	}
@@ -573,18 +1081,38 @@ func (q *ColumnQueryAPI) selectSingle(ctx context.Context, s *pb.SingleProfile)
 	return p, nil
 }
 
-func (q *ColumnQueryAPI) findSingle(ctx context.Context, query string, t time.Time) (*profile.Profile, error) {
-	requestedTime := timestamp.FromTime(t)
-
+func (q *ColumnQueryAPI) findSingle(ctx context.Context, query string, t time.Time, pprofLabels bool) (*profile.Profile, error) {
 	ctx, span := q.tracer.Start(ctx, "findSingle")
 	span.SetAttributes(attribute.String("query", query))
 	span.SetAttributes(attribute.Int64("time", t.Unix()))
 	defer span.End()
 
-	meta, selectorExprs, err := queryToFilterExprs(query)
+	meta, ar, err := q.findSingleRecord(ctx, query, t, pprofLabels)
 	if err != nil {
 		return nil, err
 	}
+	defer ar.Release()
+
+	return parcacol.ArrowRecordToStacktraceSamples(
+		ctx,
+		q.metastore,
+		ar,
+		"sum(value)",
+		meta,
+	)
+}
+
+// findSingleRecord returns the values of the stacktraces of the profile at
+// the requested time, before the stacktraces are resolved. The values are
+// split by their pprof labels if requested. The returned record must be
+// released by the caller.
+func (q *ColumnQueryAPI) findSingleRecord(ctx context.Context, query string, t time.Time, pprofLabels bool) (profile.Meta, arrow.Record, error) {
+	requestedTime := timestamp.FromTime(t)
+
+	meta, selectorExprs, err := queryToFilterExprs(ctx, query)
+	if err != nil {
+		return profile.Meta{}, nil, err
+	}
 
 	filterExpr := logicalplan.And(
 		append(
@@ -598,9 +1126,7 @@ func (q *ColumnQueryAPI) findSingle(ctx context.Context, query string, t time.Ti
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
-			logicalplan.Col("stacktrace"),
-			logicalplan.DynCol("pprof_labels"),
-			logicalplan.DynCol("pprof_num_labels"),
+			stacktraceGroupBy(pprofLabels)...,
 		).
 		Execute(ctx, func(r arrow.Record) error {
 			r.Retain()
@@ -608,44 +1134,57 @@ func (q *ColumnQueryAPI) findSingle(ctx context.Context, query string, t time.Ti
 			return nil
 		})
 	if err != nil {
-		return nil, fmt.Errorf("execute query: %w", err)
+		return profile.Meta{}, nil, fmt.Errorf("execute query: %w", err)
 	}
-	defer ar.Release()
 
-	return parcacol.ArrowRecordToStacktraceSamples(
-		ctx,
-		q.metastore,
-		ar,
-		"sum(value)",
-		profile.Meta{
-			Name:       meta.Name,
-			SampleType: meta.SampleType,
-			PeriodType: meta.PeriodType,
-			Timestamp:  requestedTime,
-		},
-	)
+	return profile.Meta{
+		Name:       meta.Name,
+		SampleType: meta.SampleType,
+		PeriodType: meta.PeriodType,
+		Timestamp:  requestedTime,
+	}, recordOrEmpty(ar), nil
 }
 
-func (q *ColumnQueryAPI) mergeRequest(ctx context.Context, m *pb.MergeProfile, reportType pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
+func (q *ColumnQueryAPI) mergeRequest(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
 	ctx, span := q.tracer.Start(ctx, "mergeRequest")
 	defer span.End()
 
-	p, err := q.selectMerge(ctx, m)
+	p, err := q.selectMerge(ctx, req.GetMerge(), needsPprofLabels(req))
 	if err != nil {
 		return nil, err
 	}
 
-	return q.renderReport(ctx, p, reportType)
+	return q.renderReport(ctx, p, req)
 }
 
-func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile) (*profile.Profile, error) {
+func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile, pprofLabels bool) (*profile.Profile, error) {
 	ctx, span := q.tracer.Start(ctx, "selectMerge")
 	defer span.End()
 
-	meta, selectorExprs, err := queryToFilterExprs(m.Query)
+	meta, ar, err := q.selectMergeRecord(ctx, m, pprofLabels)
 	if err != nil {
 		return nil, err
 	}
+	defer ar.Release()
+
+	return parcacol.ArrowRecordToStacktraceSamples(
+		ctx,
+		q.metastore,
+		ar,
+		"sum(value)",
+		meta,
+	)
+}
+
+// selectMergeRecord returns the summed values of the stacktraces of all
+// profiles selected by the merge, before the stacktraces are resolved. The
+// values are split by their pprof labels if requested. The returned record
+// must be released by the caller.
+func (q *ColumnQueryAPI) selectMergeRecord(ctx context.Context, m *pb.MergeProfile, pprofLabels bool) (profile.Meta, arrow.Record, error) {
+	meta, selectorExprs, err := queryToFilterExprs(ctx, m.Query)
+	if err != nil {
+		return profile.Meta{}, nil, err
+	}
 
 	start := timestamp.FromTime(m.Start.AsTime())
 	end := timestamp.FromTime(m.End.AsTime())
@@ -663,7 +1202,7 @@ func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile) (*
 		Filter(filterExpr).
 		Aggregate(
 			logicalplan.Sum(logicalplan.Col("value")),
-			logicalplan.Col("stacktrace"),
+			stacktraceGroupBy(pprofLabels)...,
 		).
 		Execute(ctx, func(r arrow.Record) error {
 			r.Retain()
@@ -671,84 +1210,119 @@ func (q *ColumnQueryAPI) selectMerge(ctx context.Context, m *pb.MergeProfile) (*
 			return nil
 		})
 	if err != nil {
-		return nil, err
+		return profile.Meta{}, nil, err
 	}
-	defer ar.Release()
 
-	return parcacol.ArrowRecordToStacktraceSamples(
-		ctx,
-		q.metastore,
-		ar,
-		"sum(value)",
-		profile.Meta{
-			Name:       meta.Name,
-			SampleType: meta.SampleType,
-			PeriodType: meta.PeriodType,
-			Timestamp:  start,
-		},
-	)
+	return profile.Meta{
+		Name:       meta.Name,
+		SampleType: meta.SampleType,
+		PeriodType: meta.PeriodType,
+		Timestamp:  start,
+	}, recordOrEmpty(ar), nil
+}
+
+// needsPprofLabels returns whether the report of the request needs the pprof
+// labels of the samples. Only pprof reports and flamegraphs grouped by pprof
+// labels do, all other reports are built from the values of the stacktraces
+// alone.
+func needsPprofLabels(req *pb.QueryRequest) bool {
+	switch req.GetReportType() {
+	case pb.QueryRequest_REPORT_TYPE_PPROF:
+		return true
+	case pb.QueryRequest_REPORT_TYPE_FLAMEGRAPH_UNSPECIFIED:
+		return len(req.GroupBy) > 0
+	default:
+		return false
+	}
 }
 
-func (q *ColumnQueryAPI) diffRequest(ctx context.Context, d *pb.DiffProfile, reportType pb.QueryRequest_ReportType) (*pb.QueryResponse, error) {
+// stacktraceGroupBy returns the columns the values of the stacktraces are
+// grouped by. Grouping by the pprof labels splits the value of a stacktrace
+// into one row per distinct set of pprof labels.
+func stacktraceGroupBy(pprofLabels bool) []logicalplan.Expr {
+	if !pprofLabels {
+		return []logicalplan.Expr{logicalplan.Col(parcacol.ColumnStacktrace)}
+	}
+
+	return []logicalplan.Expr{
+		logicalplan.Col(parcacol.ColumnStacktrace),
+		logicalplan.DynCol(parcacol.ColumnPprofLabels),
+		logicalplan.DynCol(parcacol.ColumnPprofNumLabels),
+	}
+}
+
+// recordOrEmpty returns the record, or an empty record if the query did not
+// return one.
+func recordOrEmpty(ar arrow.Record) arrow.Record {
+	if ar != nil {
+		return ar
+	}
+	return array.NewRecord(arrow.NewSchema(nil, nil), nil, 0)
+}
+
+func (q *ColumnQueryAPI) diffRequest(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
 	ctx, span := q.tracer.Start(ctx, "diffRequest")
 	defer span.End()
 
+	d := req.GetDiff()
 	if d == nil {
 		return nil, status.Error(codes.InvalidArgument, "requested diff mode, but did not provide parameters for diff")
 	}
 
-	base, err := q.selectProfileForDiff(ctx, d.A)
+	// The status code of errors is kept, so that not finding one of the
+	// profiles is reported as such.
+	_, base, err := q.selectRecordForDiff(ctx, d.A, needsPprofLabels(req))
 	if err != nil {
-		return nil, fmt.Errorf("reading base profile: %w", err)
+		return nil, status.Errorf(status.Code(err), "reading base profile: %s", status.Convert(err).Message())
 	}
+	defer base.Release()
 
-	compare, err := q.selectProfileForDiff(ctx, d.B)
+	meta, compare, err := q.selectRecordForDiff(ctx, d.B, needsPprofLabels(req))
 	if err != nil {
-		return nil, fmt.Errorf("reading compared profile: %w", err)
+		return nil, status.Errorf(status.Code(err), "reading compared profile: %s", status.Convert(err).Message())
 	}
+	defer compare.Release()
 
-	// TODO: This is cheating a bit. This should be done with a sub-query in the columnstore.
-	diff := &profile.Profile{}
-
-	// TODO: Use parcacol.Sample for comparing these
-	for i := range compare.Samples {
-		diff.Samples = append(diff.Samples, &profile.SymbolizedSample{
-			Locations: compare.Samples[i].Locations,
-			Value:     compare.Samples[i].Value,
-			DiffValue: compare.Samples[i].Value,
-			Label:     compare.Samples[i].Label,
-			NumLabel:  compare.Samples[i].NumLabel,
-		})
-	}
-
-	for i := range base.Samples {
-		diff.Samples = append(diff.Samples, &profile.SymbolizedSample{
-			Locations: base.Samples[i].Locations,
-			DiffValue: -base.Samples[i].Value,
-			Label:     base.Samples[i].Label,
-			NumLabel:  base.Samples[i].NumLabel,
-		})
+	// The selections are joined by their stacktrace IDs, so that each
+	// stacktrace is only resolved once.
+	diff, err := parcacol.ArrowRecordsToDiffStacktraceSamples(
+		ctx,
+		q.metastore,
+		base,
+		compare,
+		"sum(value)",
+		meta,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("diff profiles: %w", err)
 	}
 
-	return q.renderReport(ctx, diff, reportType)
+	return q.renderReport(ctx, diff, req)
 }
 
-func (q *ColumnQueryAPI) selectProfileForDiff(ctx context.Context, s *pb.ProfileDiffSelection) (*profile.Profile, error) {
+// selectRecordForDiff returns the values of the stacktraces of the selection,
+// before the stacktraces are resolved. The returned record must be released by
+// the caller.
+func (q *ColumnQueryAPI) selectRecordForDiff(ctx context.Context, s *pb.ProfileDiffSelection, pprofLabels bool) (profile.Meta, arrow.Record, error) {
 	switch s.Mode {
 	case pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED:
-		p, err := q.selectSingle(ctx, s.GetSingle())
+		meta, ar, err := q.findSingleRecord(ctx, s.GetSingle().Query, s.GetSingle().Time.AsTime(), pprofLabels)
 		if err != nil {
-			return nil, fmt.Errorf("selecting single profile: %w", err)
+			return profile.Meta{}, nil, err
+		}
+		if ar.NumRows() == 0 {
+			ar.Release()
+			return profile.Meta{}, nil, status.Error(codes.NotFound, "could not find profile at requested time and selectors")
 		}
-		return p, err
+		return meta, ar, nil
 	case pb.ProfileDiffSelection_MODE_MERGE:
-		p, err := q.selectMerge(ctx, s.GetMerge())
+		meta, ar, err := q.selectMergeRecord(ctx, s.GetMerge(), pprofLabels)
 		if err != nil {
-			return nil, fmt.Errorf("selecting merged profile: %w", err)
+			return profile.Meta{}, nil, err
 		}
-		return p, err
+		return meta, ar, nil
 	default:
-		return nil, status.Error(codes.InvalidArgument, "unknown mode for diff profile selection")
+		return profile.Meta{}, nil, status.Error(codes.InvalidArgument, "unknown mode for diff profile selection")
 	}
 }
 
@@ -18,11 +18,15 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/tls"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/apache/arrow/go/v8/arrow"
+	"github.com/apache/arrow/go/v8/arrow/array"
 	"github.com/apache/arrow/go/v8/arrow/memory"
 	"github.com/go-kit/log"
 	columnstore "github.com/polarsignals/frostdb"
@@ -37,6 +41,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pprofpb "github.com/parca-dev/parca/gen/proto/go/google/pprof"
@@ -48,6 +53,7 @@ import (
 	"github.com/parca-dev/parca/pkg/metastoretest"
 	"github.com/parca-dev/parca/pkg/parcacol"
 	"github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 func getShareServerConn(t Testing) share.ShareClient {
@@ -91,6 +97,7 @@ func TestColumnQueryAPIQueryRangeEmpty(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -194,6 +201,7 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -209,6 +217,117 @@ func TestColumnQueryAPIQueryRange(t *testing.T) {
 	require.Equal(t, 1, len(res.Series))
 	require.Equal(t, 1, len(res.Series[0].Labelset.Labels))
 	require.Equal(t, 10, len(res.Series[0].Samples))
+
+	total := int64(0)
+	for i, sample := range res.Series[0].Samples {
+		if i > 0 {
+			require.True(t, sample.Timestamp.AsTime().After(res.Series[0].Samples[i-1].Timestamp.AsTime()))
+		}
+		total += sample.Value
+	}
+
+	// All profiles fall into the same step.
+	res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+		Step:  durationpb.New(100 * 365 * 24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, 1, len(res.Series[0].Samples))
+	require.Equal(t, total, res.Series[0].Samples[0].Value)
+
+	p := &pprofpb.Profile{}
+	err = p.UnmarshalVT(MustReadAllGzip(t, dir+files[0].Name()))
+	require.NoError(t, err)
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "other",
+	}}, p, false)
+	require.NoError(t, err)
+
+	res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Series))
+	otherTotal := int64(0)
+	for _, series := range res.Series {
+		if series.Labelset.Labels[0].Value == "other" {
+			otherTotal = series.Samples[0].Value
+		}
+	}
+
+	res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query:   `memory:alloc_objects:count:space:bytes`,
+		Start:   timestamppb.New(timestamp.Time(0)),
+		End:     timestamppb.New(timestamp.Time(9223372036854775807)),
+		GroupBy: []string{"job"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Series))
+
+	// Grouping by a label none of the series have merges all series. The
+	// profile of the other job was taken at the same time as one of the
+	// profiles of the default job.
+	for _, tc := range []struct {
+		aggregation pb.QueryRangeRequest_Aggregation
+		total       int64
+	}{
+		{pb.QueryRangeRequest_AGGREGATION_SUM_UNSPECIFIED, total + otherTotal},
+		{pb.QueryRangeRequest_AGGREGATION_MAX, total},
+		{pb.QueryRangeRequest_AGGREGATION_AVG, total},
+	} {
+		res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+			Query:       `memory:alloc_objects:count:space:bytes`,
+			Start:       timestamppb.New(timestamp.Time(0)),
+			End:         timestamppb.New(timestamp.Time(9223372036854775807)),
+			GroupBy:     []string{"namespace"},
+			Aggregation: tc.aggregation,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res.Series))
+		require.Equal(t, 0, len(res.Series[0].Labelset.Labels))
+		require.Equal(t, 10, len(res.Series[0].Samples))
+
+		sum := int64(0)
+		for _, sample := range res.Series[0].Samples {
+			sum += sample.Value
+		}
+		require.Equal(t, tc.total, sum, tc.aggregation.String())
+	}
+
+	_, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query:   `memory:alloc_objects:count:space:bytes`,
+		Start:   timestamppb.New(timestamp.Time(0)),
+		End:     timestamppb.New(timestamp.Time(9223372036854775807)),
+		GroupBy: []string{"not-a-label"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Series))
+	require.Equal(t, "default", res.Series[0].Labelset.Labels[0].Value)
+
+	_, err = api.QueryRange(ctx, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+		Step:  durationpb.New(-time.Second),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestColumnQueryAPIQuerySingle(t *testing.T) {
@@ -263,6 +382,7 @@ func TestColumnQueryAPIQuerySingle(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -349,6 +469,7 @@ func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -366,6 +487,287 @@ func TestColumnQueryAPIQueryFgprof(t *testing.T) {
 	require.Equal(t, 1, len(res.Series[0].Samples))
 }
 
+func TestColumnQueryAPIQueryPprofLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+	metastore := metastore.NewInProcessClient(m)
+
+	lres, err := m.GetOrCreateLocations(ctx, &metastorepb.GetOrCreateLocationsRequest{
+		Locations: []*metastorepb.Location{{Address: 0x1}, {Address: 0x2}},
+	})
+	require.NoError(t, err)
+
+	sres, err := m.GetOrCreateStacktraces(ctx, &metastorepb.GetOrCreateStacktracesRequest{
+		Stacktraces: []*metastorepb.Stacktrace{
+			{LocationIds: []string{lres.Locations[0].Id}},
+			{LocationIds: []string{lres.Locations[1].Id}},
+		},
+	})
+	require.NoError(t, err)
+
+	normalizer := parcacol.NewNormalizer(metastore)
+	ingester := parcacol.NewIngester(logger, normalizer, table)
+	err = ingester.IngestProfile(
+		ctx,
+		labels.Labels{{Name: "job", Value: "default"}},
+		&profile.NormalizedProfile{
+			Meta: profile.Meta{
+				Name:       "cpu",
+				PeriodType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"},
+				SampleType: profile.ValueType{Type: "samples", Unit: "count"},
+				Timestamp:  1,
+			},
+			Samples: []*profile.NormalizedSample{{
+				StacktraceID: sres.Stacktraces[0].Id,
+				Value:        1,
+				Label:        map[string]string{"endpoint": "/checkout"},
+				NumLabel:     map[string]int64{"items": 3},
+			}, {
+				StacktraceID: sres.Stacktraces[1].Id,
+				Value:        2,
+				Label:        map[string]string{"endpoint": "/cart"},
+			}, {
+				StacktraceID: sres.Stacktraces[1].Id,
+				Value:        4,
+			}},
+		},
+	)
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		metastore,
+		getShareServerConn(t),
+		nil,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		"stacktraces",
+	)
+
+	for _, tc := range []struct {
+		query string
+		total int64
+	}{
+		{`cpu:samples:count:cpu:nanoseconds{job="default"}`, 7},
+		{`cpu:samples:count:cpu:nanoseconds{job="default", pprof_endpoint="/checkout"}`, 1},
+		{`cpu:samples:count:cpu:nanoseconds{pprof_endpoint=~"/c.*"}`, 3},
+		{`cpu:samples:count:cpu:nanoseconds{pprof_endpoint="/cart"}`, 2},
+		{`cpu:samples:count:cpu:nanoseconds{pprof_num_items="3"}`, 1},
+		{`cpu:samples:count:cpu:nanoseconds{pprof_endpoint=~"/c.*", pprof_num_items!="3"}`, 2},
+	} {
+		res, err := api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_MERGE,
+			Options: &pb.QueryRequest_Merge{
+				Merge: &pb.MergeProfile{
+					Query: tc.query,
+					Start: timestamppb.New(timestamp.Time(0)),
+					End:   timestamppb.New(timestamp.Time(2)),
+				},
+			},
+		})
+		require.NoError(t, err, tc.query)
+		require.Equal(t, tc.total, res.Report.(*pb.QueryResponse_Flamegraph).Flamegraph.Total, tc.query)
+
+		res, err = api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_SINGLE_UNSPECIFIED,
+			Options: &pb.QueryRequest_Single{
+				Single: &pb.SingleProfile{
+					Query: tc.query,
+					Time:  timestamppb.New(timestamp.Time(1)),
+				},
+			},
+		})
+		require.NoError(t, err, tc.query)
+		require.Equal(t, tc.total, res.Report.(*pb.QueryResponse_Flamegraph).Flamegraph.Total, tc.query)
+	}
+
+	for _, query := range []string{
+		`cpu:samples:count:cpu:nanoseconds{pprof_num_items=~"3"}`,
+		`cpu:samples:count:cpu:nanoseconds{pprof_num_items="three"}`,
+	} {
+		_, err := api.Query(ctx, &pb.QueryRequest{
+			Mode: pb.QueryRequest_MODE_SINGLE_UNSPECIFIED,
+			Options: &pb.QueryRequest_Single{
+				Single: &pb.SingleProfile{
+					Query: query,
+					Time:  timestamppb.New(timestamp.Time(1)),
+				},
+			},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), query)
+	}
+
+	for _, req := range []*pb.QueryRequest{{
+		Mode: pb.QueryRequest_MODE_MERGE,
+		Options: &pb.QueryRequest_Merge{
+			Merge: &pb.MergeProfile{
+				Query: `cpu:samples:count:cpu:nanoseconds{job="default"}`,
+				Start: timestamppb.New(timestamp.Time(0)),
+				End:   timestamppb.New(timestamp.Time(2)),
+			},
+		},
+		GroupBy: []string{"endpoint"},
+	}, {
+		Mode: pb.QueryRequest_MODE_SINGLE_UNSPECIFIED,
+		Options: &pb.QueryRequest_Single{
+			Single: &pb.SingleProfile{
+				Query: `cpu:samples:count:cpu:nanoseconds{job="default"}`,
+				Time:  timestamppb.New(timestamp.Time(1)),
+			},
+		},
+		GroupBy: []string{"endpoint"},
+	}} {
+		res, err := api.Query(ctx, req)
+		require.NoError(t, err)
+
+		fg := res.Report.(*pb.QueryResponse_Flamegraph).Flamegraph
+		require.Equal(t, int64(7), fg.Total)
+		require.Equal(t, int32(3), fg.Height)
+		require.Equal(t, 3, len(fg.Root.Children))
+		for i, expected := range []struct {
+			value      string
+			cumulative int64
+		}{{"", 4}, {"/cart", 2}, {"/checkout", 1}} {
+			node := fg.Root.Children[i]
+			require.Equal(t, "endpoint", node.Meta.Label.Name)
+			require.Equal(t, expected.value, node.Meta.Label.Value)
+			require.Equal(t, expected.cumulative, node.Cumulative)
+			require.Equal(t, 1, len(node.Children))
+			require.Nil(t, node.Children[0].Meta.Label)
+		}
+	}
+
+	_, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_DIFF,
+		Options: &pb.QueryRequest_Diff{
+			Diff: &pb.DiffProfile{
+				A: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+					Options: &pb.ProfileDiffSelection_Single{
+						Single: &pb.SingleProfile{
+							Query: `cpu:samples:count:cpu:nanoseconds{job="default"}`,
+							Time:  timestamppb.New(timestamp.Time(1)),
+						},
+					},
+				},
+				B: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+					Options: &pb.ProfileDiffSelection_Single{
+						Single: &pb.SingleProfile{
+							Query: `cpu:samples:count:cpu:nanoseconds{job="default"}`,
+							Time:  timestamppb.New(timestamp.Time(1)),
+						},
+					},
+				},
+			},
+		},
+		GroupBy: []string{"endpoint"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	single := &pb.ProfileDiffSelection{
+		Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+		Options: &pb.ProfileDiffSelection_Single{
+			Single: &pb.SingleProfile{
+				Query: `cpu:samples:count:cpu:nanoseconds{job="default"}`,
+				Time:  timestamppb.New(timestamp.Time(1)),
+			},
+		},
+	}
+	merge := &pb.MergeProfile{
+		Query: `cpu:samples:count:cpu:nanoseconds{job="default"}`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(2)),
+	}
+
+	// Merged and diffed profiles keep the pprof labels of their samples.
+	for _, req := range []*pb.QueryRequest{{
+		Mode:       pb.QueryRequest_MODE_MERGE,
+		ReportType: pb.QueryRequest_REPORT_TYPE_PPROF,
+		Options:    &pb.QueryRequest_Merge{Merge: merge},
+	}, {
+		Mode:       pb.QueryRequest_MODE_DIFF,
+		ReportType: pb.QueryRequest_REPORT_TYPE_PPROF,
+		Options: &pb.QueryRequest_Diff{
+			Diff: &pb.DiffProfile{
+				A: single,
+				B: &pb.ProfileDiffSelection{
+					Mode:    pb.ProfileDiffSelection_MODE_MERGE,
+					Options: &pb.ProfileDiffSelection_Merge{Merge: merge},
+				},
+			},
+		},
+	}} {
+		res, err := api.Query(ctx, req)
+		require.NoError(t, err, req.Mode)
+
+		pp := &pprofpb.Profile{}
+		require.NoError(t, pp.UnmarshalVT(MustDecompressGzip(t, res.Report.(*pb.QueryResponse_Pprof).Pprof)))
+		require.Equal(t, 3, len(pp.Sample), req.Mode)
+
+		sampleLabels := map[string]int64{}
+		sampleLocations := map[string][]uint64{}
+		for _, sample := range pp.Sample {
+			endpoint := ""
+			for _, label := range sample.Label {
+				key := pp.StringTable[label.Key]
+				switch key {
+				case "endpoint":
+					endpoint = pp.StringTable[label.Str]
+				case "items":
+					require.Equal(t, int64(3), label.Num)
+					require.Equal(t, int64(1), sample.Value[0])
+				default:
+					t.Fatalf("unexpected label %q", key)
+				}
+			}
+			sampleLabels[endpoint] = sample.Value[0]
+			sampleLocations[endpoint] = sample.LocationId
+		}
+		require.Equal(t, map[string]int64{"": 4, "/cart": 2, "/checkout": 1}, sampleLabels, req.Mode)
+		// Samples of the same stacktrace with different labels share it.
+		require.Equal(t, sampleLocations[""], sampleLocations["/cart"], req.Mode)
+		require.NotEqual(t, sampleLocations[""], sampleLocations["/checkout"], req.Mode)
+	}
+
+	// Reports that do not need the pprof labels only group by stacktrace.
+	_, ar, err := api.selectMergeRecord(ctx, merge, false)
+	require.NoError(t, err)
+	defer ar.Release()
+	require.Equal(t, int64(2), ar.NumRows())
+	for _, field := range ar.Schema().Fields() {
+		require.False(t, strings.HasPrefix(field.Name, parcacol.ColumnPprofLabels), field.Name)
+	}
+}
+
 func TestColumnQueryAPIQueryDiff(t *testing.T) {
 	t.Parallel()
 
@@ -513,6 +915,7 @@ func TestColumnQueryAPIQueryDiff(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -620,6 +1023,100 @@ func TestColumnQueryAPIQueryDiff(t *testing.T) {
 	require.Equal(t, 2, len(testProf.Sample))
 	require.Equal(t, []int64{2}, testProf.Sample[0].Value)
 	require.Equal(t, []int64{-1}, testProf.Sample[1].Value)
+
+	// Stacktraces found in both selections are joined into a single sample.
+	res, err = api.Query(ctx, &pb.QueryRequest{
+		Mode:       pb.QueryRequest_MODE_DIFF,
+		ReportType: *pb.QueryRequest_REPORT_TYPE_PPROF.Enum(),
+		Options: &pb.QueryRequest_Diff{
+			Diff: &pb.DiffProfile{
+				A: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+					Options: &pb.ProfileDiffSelection_Single{
+						Single: &pb.SingleProfile{
+							Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+							Time:  timestamppb.New(timestamp.Time(1)),
+						},
+					},
+				},
+				B: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_MERGE,
+					Options: &pb.ProfileDiffSelection_Merge{
+						Merge: &pb.MergeProfile{
+							Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+							Start: timestamppb.New(timestamp.Time(0)),
+							End:   timestamppb.New(timestamp.Time(3)),
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	testProf = &pprofpb.Profile{}
+	err = testProf.UnmarshalVT(MustDecompressGzip(t, res.Report.(*pb.QueryResponse_Pprof).Pprof))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(testProf.Sample))
+
+	res, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_DIFF,
+		Options: &pb.QueryRequest_Diff{
+			Diff: &pb.DiffProfile{
+				A: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+					Options: &pb.ProfileDiffSelection_Single{
+						Single: &pb.SingleProfile{
+							Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+							Time:  timestamppb.New(timestamp.Time(1)),
+						},
+					},
+				},
+				B: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_MERGE,
+					Options: &pb.ProfileDiffSelection_Merge{
+						Merge: &pb.MergeProfile{
+							Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+							Start: timestamppb.New(timestamp.Time(0)),
+							End:   timestamppb.New(timestamp.Time(3)),
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	fg = res.Report.(*pb.QueryResponse_Flamegraph).Flamegraph
+	require.Equal(t, int64(3), fg.Total)
+	require.Equal(t, int64(2), fg.Root.Diff)
+
+	_, err = api.Query(ctx, &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_DIFF,
+		Options: &pb.QueryRequest_Diff{
+			Diff: &pb.DiffProfile{
+				A: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+					Options: &pb.ProfileDiffSelection_Single{
+						Single: &pb.SingleProfile{
+							Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+							Time:  timestamppb.New(timestamp.Time(5)),
+						},
+					},
+				},
+				B: &pb.ProfileDiffSelection{
+					Mode: pb.ProfileDiffSelection_MODE_SINGLE_UNSPECIFIED,
+					Options: &pb.ProfileDiffSelection_Single{
+						Single: &pb.SingleProfile{
+							Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+							Time:  timestamppb.New(timestamp.Time(2)),
+						},
+					},
+				},
+			},
+		},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
 }
 
 func TestColumnQueryAPITypes(t *testing.T) {
@@ -676,6 +1173,7 @@ func TestColumnQueryAPITypes(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -749,6 +1247,7 @@ func TestColumnQueryAPILabelNames(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -815,6 +1314,7 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 		tracer,
 		metastore,
 		getShareServerConn(t),
+		nil,
 		query.NewEngine(
 			memory.DefaultAllocator,
 			colDB.TableProvider(),
@@ -830,3 +1330,388 @@ func TestColumnQueryAPILabelValues(t *testing.T) {
 		"default",
 	}, res.LabelValues)
 }
+
+func TestColumnQueryAPILabelsMatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	fileContent := MustReadAllGzip(t, "testdata/alloc_objects.pb.gz")
+	p := &pprofpb.Profile{}
+	err = p.UnmarshalVT(fileContent)
+	require.NoError(t, err)
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore)
+	ingester := parcacol.NewIngester(logger, normalizer, table)
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "instance",
+		Value: "localhost",
+	}, {
+		Name:  "job",
+		Value: "a",
+	}}, p, false)
+	require.NoError(t, err)
+	err = ingester.Ingest(ctx, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "b",
+	}}, p, false)
+	require.NoError(t, err)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		metastore,
+		getShareServerConn(t),
+		nil,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		"stacktraces",
+	)
+
+	lres, err := api.Labels(ctx, &pb.LabelsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"instance", "job"}, lres.LabelNames)
+	require.Empty(t, lres.Warnings)
+
+	lres, err = api.Labels(ctx, &pb.LabelsRequest{Match: []string{`{job="b"}`}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"job"}, lres.LabelNames)
+
+	vres, err := api.Values(ctx, &pb.ValuesRequest{LabelName: "job"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, vres.LabelValues)
+
+	vres, err = api.Values(ctx, &pb.ValuesRequest{
+		LabelName: "job",
+		Match:     []string{`memory:alloc_objects:count:space:bytes{instance="localhost"}`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, vres.LabelValues)
+
+	vres, err = api.Values(ctx, &pb.ValuesRequest{
+		LabelName: "instance",
+		Match:     []string{`{job="b"}`},
+	})
+	require.NoError(t, err)
+	require.Empty(t, vres.LabelValues)
+
+	// No samples were written before the end of the time range.
+	lres, err = api.Labels(ctx, &pb.LabelsRequest{
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.NoError(t, err)
+	require.Empty(t, lres.LabelNames)
+
+	vres, err = api.Values(ctx, &pb.ValuesRequest{
+		LabelName: "job",
+		Start:     timestamppb.New(timestamp.Time(0)),
+		End:       timestamppb.New(timestamp.Time(9223372036854775807)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, vres.LabelValues)
+
+	_, err = api.Labels(ctx, &pb.LabelsRequest{Match: []string{`{job=}`}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSortedLabelResultsTruncated(t *testing.T) {
+	seen := map[string]struct{}{}
+	for i := 0; i < maxLabelResults+1; i++ {
+		seen[fmt.Sprintf("%06d", i)] = struct{}{}
+	}
+
+	vals, warnings := sortedLabelResults(seen, "label values")
+	require.Equal(t, maxLabelResults, len(vals))
+	require.Equal(t, "000000", vals[0])
+	require.Len(t, warnings, 1)
+}
+
+func TestColumnQueryAPITenants(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastore.NewBadgerMetastore(
+		logger,
+		reg,
+		tracer,
+	)
+
+	fileContent := MustReadAllGzip(t, "testdata/alloc_objects.pb.gz")
+	p := &pprofpb.Profile{}
+	err = p.UnmarshalVT(fileContent)
+	require.NoError(t, err)
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore)
+	ingester := parcacol.NewIngester(logger, normalizer, table)
+
+	ctxA := tenant.NewContext(ctx, "a")
+	err = ingester.Ingest(ctxA, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "a",
+	}, {
+		Name:  "team",
+		Value: "x",
+	}}, p, false)
+	require.NoError(t, err)
+
+	ctxB := tenant.NewContext(ctx, "b")
+	err = ingester.Ingest(ctxB, labels.Labels{{
+		Name:  "__name__",
+		Value: "memory",
+	}, {
+		Name:  "job",
+		Value: "b",
+	}}, p, false)
+	require.NoError(t, err)
+
+	tenants, err := m.Tenants(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "a", "b"}, tenants)
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		metastore,
+		getShareServerConn(t),
+		nil,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		"stacktraces",
+	)
+
+	lres, err := api.Labels(ctxA, &pb.LabelsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"job", "team"}, lres.LabelNames)
+
+	lres, err = api.Labels(ctxB, &pb.LabelsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"job"}, lres.LabelNames)
+
+	vres, err := api.Values(ctxA, &pb.ValuesRequest{LabelName: "job"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, vres.LabelValues)
+
+	tres, err := api.ProfileTypes(ctxB, &pb.ProfileTypesRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, tres.Types)
+
+	tres, err = api.ProfileTypes(ctx, &pb.ProfileTypesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, tres.Types)
+
+	req := &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes{job="a"}`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+	}
+	qres, err := api.QueryRange(ctxA, req)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(qres.Series))
+
+	_, err = api.QueryRange(ctxB, req)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Without a label selector, tenant A only sees its own series.
+	qres, err = api.QueryRange(ctxA, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(qres.Series))
+	for _, l := range qres.Series[0].Labelset.Labels {
+		if l.Name == "job" {
+			require.Equal(t, "a", l.Value)
+		}
+	}
+
+	// Selecting the series of tenant B from tenant A finds nothing.
+	_, err = api.QueryRange(ctxA, &pb.QueryRangeRequest{
+		Query: `memory:alloc_objects:count:space:bytes{job="b"}`,
+		Start: timestamppb.New(timestamp.Time(0)),
+		End:   timestamppb.New(timestamp.Time(9223372036854775807)),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestColumnQueryAPISeries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	reg := prometheus.NewRegistry()
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+	col := columnstore.New(
+		reg,
+		8196,
+		64*1024*1024,
+	)
+	colDB, err := col.DB("parca")
+	require.NoError(t, err)
+	table, err := colDB.Table(
+		"stacktraces",
+		columnstore.NewTableConfig(
+			parcacol.Schema(),
+		),
+		logger,
+	)
+	require.NoError(t, err)
+	m := metastoretest.NewTestMetastore(
+		t,
+		logger,
+		reg,
+		tracer,
+	)
+
+	fileContent := MustReadAllGzip(t, "testdata/alloc_objects.pb.gz")
+	p := &pprofpb.Profile{}
+	err = p.UnmarshalVT(fileContent)
+	require.NoError(t, err)
+
+	metastore := metastore.NewInProcessClient(m)
+	normalizer := parcacol.NewNormalizer(metastore)
+	ingester := parcacol.NewIngester(logger, normalizer, table)
+	for _, job := range []string{"a", "b"} {
+		err = ingester.Ingest(ctx, labels.Labels{{
+			Name:  "__name__",
+			Value: "memory",
+		}, {
+			Name:  "job",
+			Value: job,
+		}}, p, false)
+		require.NoError(t, err)
+	}
+
+	api := NewColumnQueryAPI(
+		logger,
+		tracer,
+		metastore,
+		getShareServerConn(t),
+		nil,
+		query.NewEngine(
+			memory.DefaultAllocator,
+			colDB.TableProvider(),
+		),
+		"stacktraces",
+	)
+
+	start := timestamppb.New(timestamp.Time(0))
+	end := timestamppb.New(timestamp.Time(9223372036854775807))
+
+	// Without selectors all series of all profile types are returned.
+	res, err := api.Series(ctx, &pb.SeriesRequest{Start: start, End: end})
+	require.NoError(t, err)
+	require.Equal(t, 8, len(res.Series))
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{`{job="a"}`},
+		Start: start,
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(res.Series))
+	for _, s := range res.Series {
+		require.Equal(t, "job", s.Labelset.Labels[0].Name)
+		require.Equal(t, "a", s.Labelset.Labels[0].Value)
+	}
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{
+			`memory:alloc_objects:count:space:bytes{job="a"}`,
+			`memory:alloc_objects:count:space:bytes{job="b"}`,
+		},
+		Start: start,
+		End:   end,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Series))
+	require.Equal(t, "a", res.Series[0].Labelset.Labels[0].Value)
+	require.Equal(t, "b", res.Series[1].Labelset.Labels[0].Value)
+	require.Equal(t, "alloc_objects", res.Series[1].ProfileType.SampleType)
+
+	res, err = api.Series(ctx, &pb.SeriesRequest{
+		Match: []string{`{job="a"}`},
+		Start: start,
+		End:   timestamppb.New(timestamp.Time(1)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res.Series))
+}
+
+func TestLabelColumnsFromRecord(t *testing.T) {
+	t.Parallel()
+
+	b := array.NewInt64Builder(memory.DefaultAllocator)
+	defer b.Release()
+	b.Append(1)
+	col := b.NewArray()
+	defer col.Release()
+
+	ar := array.NewRecord(
+		arrow.NewSchema([]arrow.Field{{Name: "labels.job", Type: arrow.PrimitiveTypes.Int64}}, nil),
+		[]arrow.Array{col},
+		1,
+	)
+	defer ar.Release()
+
+	_, err := labelColumnsFromRecord(ar, []int{0})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
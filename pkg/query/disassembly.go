@@ -0,0 +1,189 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+	"github.com/parca-dev/parca/pkg/symbol/disasm"
+	"github.com/parca-dev/parca/pkg/symbolizer"
+)
+
+type addressValues struct {
+	flat       int64
+	flatDiff   int64
+	cumulative int64
+	diff       int64
+}
+
+type mappingAddresses struct {
+	mapping *metastorev1alpha1.Mapping
+	values  map[uint64]*addressValues
+}
+
+// GenerateDisassembly disassembles the functions containing the addresses of
+// the locations with lines matching the reference, like `pprof -disasm` does,
+// and annotates each instruction with the values of the samples at its
+// address. Values of addresses found multiple times in the stack of a sample
+// are only counted once. Mappings whose debug info cannot be fetched, or does
+// not contain the code of the functions, are left out of the report.
+func GenerateDisassembly(
+	ctx context.Context,
+	logger log.Logger,
+	debuginfo symbolizer.DebugInfoFetcher,
+	p *parcaprofile.Profile,
+	ref *pb.SourceReference,
+) (*pb.Disassembly, error) {
+	mappings := map[string]*mappingAddresses{}
+	var cumulative int64
+
+	for _, s := range p.Samples {
+		cumulative += s.Value
+
+		seen := map[*addressValues]struct{}{}
+		for i, location := range s.Locations {
+			if location.Mapping.GetBuildId() == "" || !locationMatches(ref, location) {
+				continue
+			}
+
+			m, ok := mappings[location.Mapping.BuildId]
+			if !ok {
+				m = &mappingAddresses{
+					mapping: location.Mapping,
+					values:  map[uint64]*addressValues{},
+				}
+				mappings[location.Mapping.BuildId] = m
+			}
+
+			v, ok := m.values[location.Address]
+			if !ok {
+				v = &addressValues{}
+				m.values[location.Address] = v
+			}
+
+			if i == 0 {
+				v.flat += s.Value
+				v.flatDiff += s.DiffValue
+			}
+
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			v.cumulative += s.Value
+			v.diff += s.DiffValue
+		}
+	}
+
+	buildIDs := make([]string, 0, len(mappings))
+	for buildID := range mappings {
+		buildIDs = append(buildIDs, buildID)
+	}
+	sort.Strings(buildIDs)
+
+	disassembly := &pb.Disassembly{
+		Functions:  []*pb.DisassembledFunction{},
+		Cumulative: cumulative,
+		Unit:       p.Meta.SampleType.Unit,
+	}
+	for _, buildID := range buildIDs {
+		m := mappings[buildID]
+
+		objFile, _, err := debuginfo.FetchDebugInfo(ctx, buildID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			level.Debug(logger).Log("msg", "failed to fetch debuginfo, skipping mapping", "buildid", buildID, "err", err)
+			continue
+		}
+
+		addrs := make([]uint64, 0, len(m.values))
+		for addr := range m.values {
+			addrs = append(addrs, addr)
+		}
+
+		funcs, err := disasm.Disassemble(objFile, addrs)
+		if errors.Is(err, disasm.ErrNoCode) {
+			level.Debug(logger).Log("msg", "debuginfo does not contain code, skipping mapping", "buildid", buildID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("disassemble (BuildID: %q): %w", buildID, err)
+		}
+
+		for _, fn := range funcs {
+			disassembly.Functions = append(disassembly.Functions, disassembledFunction(m, fn))
+		}
+	}
+
+	return disassembly, nil
+}
+
+// disassembledFunction returns the function annotated with the values of the
+// addresses of the mapping.
+func disassembledFunction(m *mappingAddresses, fn disasm.Function) *pb.DisassembledFunction {
+	res := &pb.DisassembledFunction{
+		Name:         fn.Name,
+		Mapping:      m.mapping,
+		Start:        fn.Start,
+		End:          fn.End,
+		Instructions: make([]*pb.DisassembledInstruction, 0, len(fn.Instructions)),
+	}
+	for _, inst := range fn.Instructions {
+		res.Instructions = append(res.Instructions, &pb.DisassembledInstruction{
+			Address: inst.Address,
+			Text:    inst.Text,
+		})
+	}
+
+	// Addresses of callers may be adjusted to point into the call instruction,
+	// so values are attributed to the instruction containing the address.
+	for addr, v := range m.values {
+		i := sort.Search(len(fn.Instructions), func(i int) bool {
+			return fn.Instructions[i].Address+uint64(fn.Instructions[i].Size) > addr
+		})
+		if i == len(fn.Instructions) || !fn.Instructions[i].Contains(addr) {
+			continue
+		}
+
+		inst := res.Instructions[i]
+		inst.Flat += v.flat
+		inst.FlatDiff += v.flatDiff
+		inst.Cumulative += v.cumulative
+		inst.Diff += v.diff
+	}
+
+	return res
+}
+
+// locationMatches returns whether any line of the location matches the
+// reference.
+func locationMatches(ref *pb.SourceReference, location *parcaprofile.Location) bool {
+	for _, line := range location.Lines {
+		if sourceReferenceMatches(ref, line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+
+	debuginfopb "github.com/parca-dev/parca/gen/proto/go/parca/debuginfo/v1alpha1"
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/debuginfo"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+type testDebugInfoFetcher struct {
+	dir string
+}
+
+func (f testDebugInfoFetcher) FetchDebugInfo(ctx context.Context, buildID string) (string, debuginfopb.DownloadInfo_Source, error) {
+	path := filepath.Join(f.dir, buildID, "debuginfo")
+	if _, err := os.Stat(path); err != nil {
+		return "", debuginfopb.DownloadInfo_SOURCE_UNKNOWN_UNSPECIFIED, debuginfo.ErrDebugInfoNotFound
+	}
+	return path, debuginfopb.DownloadInfo_SOURCE_UPLOAD, nil
+}
+
+func TestGenerateDisassembly(t *testing.T) {
+	t.Parallel()
+
+	mapping := &metastorev1alpha1.Mapping{Id: "1", BuildId: "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085"}
+	mainFn := &metastorev1alpha1.Function{Id: "1", Name: "main.main"}
+	runtimeFn := &metastorev1alpha1.Function{Id: "2", Name: "runtime.main"}
+
+	// The address of main.main is within an instruction, the one of
+	// runtime.main is not part of the report.
+	mainLocation := &profile.Location{
+		ID:      "1",
+		Address: 0x463781,
+		Mapping: mapping,
+		Lines:   []profile.LocationLine{{Line: 7, Function: mainFn}},
+	}
+	runtimeLocation := &profile.Location{
+		ID:      "2",
+		Address: 0x435000,
+		Mapping: mapping,
+		Lines:   []profile.LocationLine{{Line: 255, Function: runtimeFn}},
+	}
+
+	p := &profile.Profile{
+		Meta: profile.Meta{SampleType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"}},
+		Samples: []*profile.SymbolizedSample{{
+			Locations: []*profile.Location{mainLocation, runtimeLocation},
+			Value:     3,
+			DiffValue: 1,
+		}, {
+			Locations: []*profile.Location{runtimeLocation},
+			Value:     2,
+		}},
+	}
+
+	disassembly, err := GenerateDisassembly(
+		context.Background(),
+		log.NewNopLogger(),
+		testDebugInfoFetcher{dir: "../symbolizer/testdata"},
+		p,
+		&pb.SourceReference{Function: "main.main"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), disassembly.Cumulative)
+	require.Equal(t, "nanoseconds", disassembly.Unit)
+	require.Len(t, disassembly.Functions, 1)
+
+	fn := disassembly.Functions[0]
+	require.Equal(t, "main.main", fn.Name)
+	require.Equal(t, mapping, fn.Mapping)
+	require.Equal(t, uint64(0x463720), fn.Start)
+
+	annotated := 0
+	for _, inst := range fn.Instructions {
+		if inst.Cumulative == 0 {
+			continue
+		}
+		annotated++
+		require.LessOrEqual(t, inst.Address, uint64(0x463781))
+		require.Equal(t, int64(3), inst.Flat)
+		require.Equal(t, int64(3), inst.Cumulative)
+		require.Equal(t, int64(1), inst.Diff)
+		require.Equal(t, int64(1), inst.FlatDiff)
+	}
+	require.Equal(t, 1, annotated)
+}
+
+func TestGenerateDisassemblySkipsMappings(t *testing.T) {
+	t.Parallel()
+
+	const buildID = "2d6912fd3dd64542f6f6294f4bf9cb6c265b3085"
+	dir := t.TempDir()
+	writeDebugInfoWithoutCode(t, filepath.Join("../symbolizer/testdata", buildID, "debuginfo"), filepath.Join(dir, buildID, "debuginfo"))
+
+	mainFn := &metastorev1alpha1.Function{Id: "1", Name: "main.main"}
+	p := &profile.Profile{
+		Meta: profile.Meta{SampleType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"}},
+		Samples: []*profile.SymbolizedSample{{
+			// The debug info of the mapping does not contain code.
+			Locations: []*profile.Location{{
+				ID:      "1",
+				Address: 0x463781,
+				Mapping: &metastorev1alpha1.Mapping{Id: "1", BuildId: buildID},
+				Lines:   []profile.LocationLine{{Line: 7, Function: mainFn}},
+			}},
+			Value: 3,
+		}, {
+			// The debug info of the mapping is not available.
+			Locations: []*profile.Location{{
+				ID:      "2",
+				Address: 0x463781,
+				Mapping: &metastorev1alpha1.Mapping{Id: "2", BuildId: "missing"},
+				Lines:   []profile.LocationLine{{Line: 7, Function: mainFn}},
+			}},
+			Value: 2,
+		}},
+	}
+
+	disassembly, err := GenerateDisassembly(
+		context.Background(),
+		log.NewNopLogger(),
+		testDebugInfoFetcher{dir: dir},
+		p,
+		&pb.SourceReference{Function: "main.main"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), disassembly.Cumulative)
+	require.Empty(t, disassembly.Functions)
+}
+
+// writeDebugInfoWithoutCode writes a copy of the object file in which the
+// text section has no data, like in separate debug info files.
+func writeDebugInfoWithoutCode(t *testing.T, src, dst string) {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+
+	f, err := elf.Open(src)
+	require.NoError(t, err)
+	defer f.Close()
+	require.Equal(t, elf.ELFCLASS64, f.Class)
+
+	shoff := binary.LittleEndian.Uint64(data[0x28:])
+	shentsize := uint64(binary.LittleEndian.Uint16(data[0x3a:]))
+	for i, s := range f.Sections {
+		if s.Name == ".text" {
+			binary.LittleEndian.PutUint32(data[shoff+uint64(i)*shentsize+4:], uint32(elf.SHT_NOBITS))
+		}
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+	require.NoError(t, os.WriteFile(dst, data, 0o644))
+}
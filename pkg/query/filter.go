@@ -0,0 +1,164 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// StackFilter filters the stacks of the samples of a profile by regular
+// expressions over function names, like the options of `go tool pprof` with
+// the same names. Unset expressions do not filter.
+type StackFilter struct {
+	// Focus keeps only the samples with a matching function on their stack.
+	Focus *regexp.Regexp
+	// Ignore drops the samples with a matching function on their stack.
+	Ignore *regexp.Regexp
+	// Hide removes the matching functions from the stacks.
+	Hide *regexp.Regexp
+	// Show keeps only the matching functions on the stacks.
+	Show *regexp.Regexp
+}
+
+// NewStackFilter returns the stack filter of the request.
+func NewStackFilter(req *pb.QueryRequest) (*StackFilter, error) {
+	f := &StackFilter{}
+	for _, r := range []struct {
+		name string
+		expr string
+		re   **regexp.Regexp
+	}{
+		{name: "focus", expr: req.GetFocus(), re: &f.Focus},
+		{name: "ignore", expr: req.GetIgnore(), re: &f.Ignore},
+		{name: "hide", expr: req.GetHide(), re: &f.Hide},
+		{name: "show", expr: req.GetShow(), re: &f.Show},
+	} {
+		if r.expr == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(r.expr)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", r.name, err)
+		}
+		*r.re = re
+	}
+
+	return f, nil
+}
+
+// Empty returns whether the filter does not filter anything.
+func (f *StackFilter) Empty() bool {
+	return f.Focus == nil && f.Ignore == nil && f.Hide == nil && f.Show == nil
+}
+
+// FilterProfile returns the profile with the samples and their stacks
+// filtered. Samples are filtered by focus and ignore before the functions of
+// their stacks are filtered by hide and show. Locations without any function
+// left are removed from the stacks.
+func FilterProfile(p *profile.Profile, f *StackFilter) *profile.Profile {
+	if f.Empty() {
+		return p
+	}
+
+	res := &profile.Profile{
+		Meta:    p.Meta,
+		Samples: make([]*profile.SymbolizedSample, 0, len(p.Samples)),
+	}
+
+	// Locations are shared by samples, so each is only filtered once.
+	filtered := map[*profile.Location]*profile.Location{}
+	for _, s := range p.Samples {
+		if f.Focus != nil && !stackMatches(s.Locations, f.Focus) {
+			continue
+		}
+		if f.Ignore != nil && stackMatches(s.Locations, f.Ignore) {
+			continue
+		}
+
+		locations := s.Locations
+		if f.Hide != nil || f.Show != nil {
+			locations = make([]*profile.Location, 0, len(s.Locations))
+			for _, location := range s.Locations {
+				l, ok := filtered[location]
+				if !ok {
+					l = f.filterLines(location)
+					filtered[location] = l
+				}
+				if l != nil {
+					locations = append(locations, l)
+				}
+			}
+		}
+
+		res.Samples = append(res.Samples, &profile.SymbolizedSample{
+			Locations: locations,
+			Value:     s.Value,
+			DiffValue: s.DiffValue,
+			Label:     s.Label,
+			NumLabel:  s.NumLabel,
+		})
+	}
+
+	return res
+}
+
+// filterLines returns the location with the lines filtered by hide and show,
+// or nil if no line is left. Locations without lines are only kept if no show
+// expression is set.
+func (f *StackFilter) filterLines(location *profile.Location) *profile.Location {
+	if len(location.Lines) == 0 {
+		if f.Show != nil {
+			return nil
+		}
+		return location
+	}
+
+	lines := make([]profile.LocationLine, 0, len(location.Lines))
+	for _, line := range location.Lines {
+		name := line.Function.GetName()
+		if f.Hide != nil && f.Hide.MatchString(name) {
+			continue
+		}
+		if f.Show != nil && !f.Show.MatchString(name) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	if len(lines) == len(location.Lines) {
+		return location
+	}
+	return withLines(location, lines)
+}
+
+// stackMatches returns whether any function on the stack matches the
+// expression.
+func stackMatches(locations []*profile.Location, re *regexp.Regexp) bool {
+	for _, location := range locations {
+		for _, line := range location.Lines {
+			if re.MatchString(line.Function.GetName()) {
+				return true
+			}
+		}
+	}
+	return false
+}
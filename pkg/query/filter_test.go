@@ -0,0 +1,162 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// filterTestStacks returns the function names of the stacks of the samples,
+// from the root to the leaf, and the values of the samples.
+func filterTestStacks(p *profile.Profile) ([][]string, []int64) {
+	stacks := make([][]string, 0, len(p.Samples))
+	values := make([]int64, 0, len(p.Samples))
+	for _, s := range p.Samples {
+		stack := []string{}
+		for i := len(s.Locations) - 1; i >= 0; i-- {
+			if len(s.Locations[i].Lines) == 0 {
+				stack = append(stack, "?")
+			}
+			for _, line := range s.Locations[i].Lines {
+				stack = append(stack, line.Function.Name)
+			}
+		}
+		stacks = append(stacks, stack)
+		values = append(values, s.Value)
+	}
+	return stacks, values
+}
+
+func TestFilterProfile(t *testing.T) {
+	t.Parallel()
+
+	newLocation := func(id string, names ...string) *profile.Location {
+		location := &profile.Location{ID: id}
+		for _, name := range names {
+			location.Lines = append(location.Lines, profile.LocationLine{
+				Function: &metastorev1alpha1.Function{Id: name, Name: name},
+			})
+		}
+		return location
+	}
+
+	mallocgc := newLocation("1", "runtime.mallocgc")
+	foo := newLocation("2", "main.foo")
+	bar := newLocation("3", "main.bar")
+	main := newLocation("4", "main.main")
+	// main.inlined is inlined into main.wrapper.
+	wrapper := newLocation("5", "main.wrapper", "main.inlined")
+	unsymbolized := newLocation("6")
+
+	p := &profile.Profile{
+		Samples: []*profile.SymbolizedSample{{
+			Locations: []*profile.Location{mallocgc, foo, main},
+			Value:     3,
+		}, {
+			Locations: []*profile.Location{bar, main},
+			Value:     2,
+		}, {
+			Locations: []*profile.Location{unsymbolized, wrapper, main},
+			Value:     1,
+		}},
+	}
+
+	testcases := []struct {
+		name   string
+		req    *pb.QueryRequest
+		stacks [][]string
+		values []int64
+	}{{
+		name: "None",
+		req:  &pb.QueryRequest{},
+		stacks: [][]string{
+			{"main.main", "main.foo", "runtime.mallocgc"},
+			{"main.main", "main.bar"},
+			{"main.main", "main.wrapper", "main.inlined", "?"},
+		},
+		values: []int64{3, 2, 1},
+	}, {
+		name: "Focus",
+		req:  &pb.QueryRequest{Focus: "foo|inlined"},
+		stacks: [][]string{
+			{"main.main", "main.foo", "runtime.mallocgc"},
+			{"main.main", "main.wrapper", "main.inlined", "?"},
+		},
+		values: []int64{3, 1},
+	}, {
+		name: "Ignore",
+		req:  &pb.QueryRequest{Ignore: "^runtime\\."},
+		stacks: [][]string{
+			{"main.main", "main.bar"},
+			{"main.main", "main.wrapper", "main.inlined", "?"},
+		},
+		values: []int64{2, 1},
+	}, {
+		name: "Hide",
+		req:  &pb.QueryRequest{Hide: "main\\.(main|wrapper)"},
+		stacks: [][]string{
+			{"main.foo", "runtime.mallocgc"},
+			{"main.bar"},
+			{"main.inlined", "?"},
+		},
+		values: []int64{3, 2, 1},
+	}, {
+		name: "Show",
+		req:  &pb.QueryRequest{Show: "^main\\."},
+		stacks: [][]string{
+			{"main.main", "main.foo"},
+			{"main.main", "main.bar"},
+			{"main.main", "main.wrapper", "main.inlined"},
+		},
+		values: []int64{3, 2, 1},
+	}, {
+		name: "FocusAndHide",
+		req:  &pb.QueryRequest{Focus: "mallocgc", Hide: "mallocgc"},
+		stacks: [][]string{
+			{"main.main", "main.foo"},
+		},
+		values: []int64{3},
+	}}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := NewStackFilter(tc.req)
+			require.NoError(t, err)
+
+			stacks, values := filterTestStacks(FilterProfile(p, f))
+			require.Equal(t, tc.stacks, stacks)
+			require.Equal(t, tc.values, values)
+		})
+	}
+
+	// The stacks of the profile itself are not modified.
+	stacks, _ := filterTestStacks(p)
+	require.Equal(t, []string{"main.main", "main.wrapper", "main.inlined", "?"}, stacks[2])
+}
+
+func TestNewStackFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStackFilter(&pb.QueryRequest{Hide: "("})
+	require.Error(t, err)
+}
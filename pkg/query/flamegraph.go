@@ -210,6 +210,10 @@ func mergeChildren(node *querypb.FlamegraphNode, compare, equals func(a, b *quer
 }
 
 func compareByName(a, b *querypb.FlamegraphNode) bool {
+	if a.Meta.Label != nil && b.Meta.Label != nil {
+		return a.Meta.Label.Value <= b.Meta.Label.Value
+	}
+
 	if a.Meta.Function != nil && b.Meta.Function == nil {
 		return false
 	}
@@ -226,6 +230,10 @@ func compareByName(a, b *querypb.FlamegraphNode) bool {
 }
 
 func equalsByName(a, b *querypb.FlamegraphNode) bool {
+	if a.Meta.Label != nil || b.Meta.Label != nil {
+		return a.Meta.Label.GetName() == b.Meta.Label.GetName() && a.Meta.Label.GetValue() == b.Meta.Label.GetValue()
+	}
+
 	if a.Meta.Function != nil && b.Meta.Function == nil {
 		return false
 	}
@@ -19,17 +19,24 @@ import (
 
 	"go.opentelemetry.io/otel/trace"
 
+	profilestorepb "github.com/parca-dev/parca/gen/proto/go/parca/profilestore/v1alpha1"
 	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
 	"github.com/parca-dev/parca/pkg/profile"
 )
 
-func GenerateFlamegraphFlat(ctx context.Context, tracer trace.Tracer, p *profile.Profile) (*pb.Flamegraph, error) {
+// GenerateFlamegraphFlat generates a flame graph of the profile. If pprof
+// label names to group by are given, the first levels of the flame graph are
+// the values of these labels, in the given order, with the stacks of the
+// samples beneath them.
+func GenerateFlamegraphFlat(ctx context.Context, tracer trace.Tracer, p *profile.Profile, groupBy []string) (*pb.Flamegraph, error) {
 	rootNode := &pb.FlamegraphNode{}
-	current := rootNode
+	labelNodes := map[string]*pb.FlamegraphNode{}
 
 	var height int32
 
 	for _, s := range p.Samples {
+		current := labelGroupNode(rootNode, labelNodes, groupBy, s)
+
 		locations := s.Locations
 		if int32(len(locations)) > height {
 			height = int32(len(locations))
@@ -76,9 +83,6 @@ func GenerateFlamegraphFlat(ctx context.Context, tracer trace.Tracer, p *profile
 		// Sum up the value to the cumulative value of the root
 		rootNode.Cumulative += s.Value
 		rootNode.Diff += s.DiffValue
-
-		// For next sample start at the root again
-		current = rootNode
 	}
 
 	flamegraph := &pb.Flamegraph{
@@ -89,8 +93,43 @@ func GenerateFlamegraphFlat(ctx context.Context, tracer trace.Tracer, p *profile
 		},
 		Total:  rootNode.Cumulative,
 		Unit:   p.Meta.SampleType.Unit,
-		Height: height + 1, // add one for the root
+		Height: height + int32(len(groupBy)) + 1, // add one for the root
 	}
 
 	return aggregateByFunction(flamegraph), nil
 }
+
+// labelGroupNode returns the node the stack of the sample is inserted
+// beneath. That is the node of the values of the sample's labels grouped by,
+// or the root if there are none. Missing nodes are created, and the value of
+// the sample is added to the nodes of each level.
+func labelGroupNode(
+	root *pb.FlamegraphNode,
+	labelNodes map[string]*pb.FlamegraphNode,
+	groupBy []string,
+	s *profile.SymbolizedSample,
+) *pb.FlamegraphNode {
+	current := root
+	key := ""
+	for _, name := range groupBy {
+		value := s.Label[name]
+		key += name + "\x00" + value + "\x00"
+
+		node, ok := labelNodes[key]
+		if !ok {
+			node = &pb.FlamegraphNode{
+				Meta: &pb.FlamegraphNodeMeta{
+					Label: &profilestorepb.Label{Name: name, Value: value},
+				},
+			}
+			labelNodes[key] = node
+			current.Children = append(current.Children, node)
+		}
+
+		node.Cumulative += s.Value
+		node.Diff += s.DiffValue
+		current = node
+	}
+
+	return current
+}
@@ -153,7 +153,7 @@ func TestGenerateFlamegraphFlat(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	fg, err := GenerateFlamegraphFlat(ctx, tracer, p)
+	fg, err := GenerateFlamegraphFlat(ctx, tracer, p, nil)
 	require.NoError(t, err)
 
 	require.True(t, proto.Equal(&pb.Flamegraph{Height: 5, Total: 6, Root: &pb.FlamegraphRootNode{
@@ -237,7 +237,7 @@ func testGenerateFlamegraphFromProfile(t *testing.T, l metastorepb.MetastoreServ
 	sp, err := parcacol.SymbolizeNormalizedProfile(ctx, l, profiles[0])
 	require.NoError(t, err)
 
-	fg, err := GenerateFlamegraphFlat(ctx, tracer, sp)
+	fg, err := GenerateFlamegraphFlat(ctx, tracer, sp, nil)
 	require.NoError(t, err)
 
 	return fg
@@ -295,7 +295,7 @@ func TestGenerateFlamegraphWithInlined(t *testing.T) {
 	symbolizedProfile, err := parcacol.SymbolizeNormalizedProfile(ctx, metastore, profiles[0])
 	require.NoError(t, err)
 
-	fg, err := GenerateFlamegraphFlat(ctx, tracer, symbolizedProfile)
+	fg, err := GenerateFlamegraphFlat(ctx, tracer, symbolizedProfile, nil)
 	require.NoError(t, err)
 
 	require.Equal(t, &pb.Flamegraph{
@@ -443,7 +443,7 @@ func TestGenerateFlamegraphWithInlinedExisting(t *testing.T) {
 	symbolizedProfile, err := parcacol.SymbolizeNormalizedProfile(ctx, metastore, profiles[0])
 	require.NoError(t, err)
 
-	fg, err := GenerateFlamegraphFlat(ctx, tracer, symbolizedProfile)
+	fg, err := GenerateFlamegraphFlat(ctx, tracer, symbolizedProfile, nil)
 	require.NoError(t, err)
 
 	expected := &pb.Flamegraph{
@@ -0,0 +1,109 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+)
+
+// TrimFlamegraph trims the nodes of the flame graph whose cumulative value is
+// below the threshold fraction of the total, and the nodes deeper than the
+// max depth, if it is not 0. If collapse is set, the nodes trimmed by the
+// threshold are collapsed into one other node per parent. The height and the
+// number of trimmed nodes of the flame graph are updated.
+func TrimFlamegraph(fg *pb.Flamegraph, threshold float32, maxDepth uint32, collapse bool) *pb.Flamegraph {
+	if threshold <= 0 && maxDepth == 0 {
+		return fg
+	}
+
+	t := &flamegraphTrimmer{
+		minValue: int64(float64(threshold) * float64(fg.Total)),
+		maxDepth: maxDepth,
+		collapse: collapse,
+	}
+
+	var height int32
+	fg.Root.Children, height = t.trimChildren(fg.Root.Children, 1)
+	fg.Height = height + 1 // add one for the root
+	fg.Trimmed += t.trimmed
+
+	return fg
+}
+
+type flamegraphTrimmer struct {
+	minValue int64
+	maxDepth uint32
+	collapse bool
+
+	trimmed int64
+}
+
+// trimChildren returns the children that are kept, and the height of the
+// tree beneath them. The depth is the depth of the children.
+func (t *flamegraphTrimmer) trimChildren(children []*pb.FlamegraphNode, depth uint32) ([]*pb.FlamegraphNode, int32) {
+	if len(children) == 0 {
+		return children, 0
+	}
+
+	if t.maxDepth > 0 && depth > t.maxDepth {
+		for _, child := range children {
+			t.trimmed += countFlamegraphNodes(child)
+		}
+		return nil, 0
+	}
+
+	var (
+		height int32
+		other  *pb.FlamegraphNode
+	)
+	kept := children[:0]
+	for _, child := range children {
+		if child.Cumulative < t.minValue {
+			t.trimmed += countFlamegraphNodes(child)
+			if t.collapse {
+				if other == nil {
+					other = &pb.FlamegraphNode{Meta: &pb.FlamegraphNodeMeta{Other: true}}
+				}
+				other.Cumulative += child.Cumulative
+				other.Diff += child.Diff
+			}
+			continue
+		}
+
+		var childHeight int32
+		child.Children, childHeight = t.trimChildren(child.Children, depth+1)
+		if childHeight+1 > height {
+			height = childHeight + 1
+		}
+		kept = append(kept, child)
+	}
+
+	if other != nil {
+		kept = append(kept, other)
+		if height == 0 {
+			height = 1
+		}
+	}
+
+	return kept, height
+}
+
+// countFlamegraphNodes returns the number of nodes of the tree of the node.
+func countFlamegraphNodes(node *pb.FlamegraphNode) int64 {
+	count := int64(1)
+	for _, child := range node.Children {
+		count += countFlamegraphNodes(child)
+	}
+	return count
+}
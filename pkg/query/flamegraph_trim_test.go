@@ -0,0 +1,132 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+)
+
+func trimTestNode(name string, cumulative int64, children ...*pb.FlamegraphNode) *pb.FlamegraphNode {
+	return &pb.FlamegraphNode{
+		Meta:       &pb.FlamegraphNodeMeta{Function: &metastorev1alpha1.Function{Name: name}},
+		Cumulative: cumulative,
+		Diff:       cumulative / 10,
+		Children:   children,
+	}
+}
+
+// trimTestFlamegraph returns a flame graph with a total of 100, and a height
+// of 4.
+func trimTestFlamegraph() *pb.Flamegraph {
+	return &pb.Flamegraph{
+		Total:  100,
+		Height: 4,
+		Root: &pb.FlamegraphRootNode{
+			Cumulative: 100,
+			Children: []*pb.FlamegraphNode{
+				trimTestNode("a", 60,
+					trimTestNode("a1", 50, trimTestNode("a11", 50)),
+					trimTestNode("a2", 10),
+				),
+				trimTestNode("b", 30, trimTestNode("b1", 30)),
+				trimTestNode("c", 5),
+				trimTestNode("d", 5),
+			},
+		},
+	}
+}
+
+func TestTrimFlamegraph(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, trimTestFlamegraph(), TrimFlamegraph(trimTestFlamegraph(), 0, 0, true))
+	})
+
+	t.Run("Threshold", func(t *testing.T) {
+		t.Parallel()
+
+		fg := TrimFlamegraph(trimTestFlamegraph(), 0.1, 0, false)
+		require.Equal(t, int64(2), fg.Trimmed)
+		require.Equal(t, int32(4), fg.Height)
+		require.Equal(t, []*pb.FlamegraphNode{
+			trimTestNode("a", 60,
+				trimTestNode("a1", 50, trimTestNode("a11", 50)),
+				trimTestNode("a2", 10),
+			),
+			trimTestNode("b", 30, trimTestNode("b1", 30)),
+		}, fg.Root.Children)
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		t.Parallel()
+
+		fg := TrimFlamegraph(trimTestFlamegraph(), 0, 2, true)
+		require.Equal(t, int64(1), fg.Trimmed)
+		require.Equal(t, int32(3), fg.Height)
+		require.Equal(t, []*pb.FlamegraphNode{
+			trimTestNode("a", 60,
+				trimTestNode("a1", 50),
+				trimTestNode("a2", 10),
+			),
+			trimTestNode("b", 30, trimTestNode("b1", 30)),
+			trimTestNode("c", 5),
+			trimTestNode("d", 5),
+		}, fg.Root.Children)
+	})
+
+	t.Run("Collapse", func(t *testing.T) {
+		t.Parallel()
+
+		fg := TrimFlamegraph(trimTestFlamegraph(), 0.2, 2, true)
+		require.Equal(t, int64(4), fg.Trimmed)
+		require.Equal(t, int32(3), fg.Height)
+		require.Equal(t, int64(100), fg.Total)
+		require.Equal(t, []*pb.FlamegraphNode{
+			trimTestNode("a", 60,
+				trimTestNode("a1", 50),
+				&pb.FlamegraphNode{Meta: &pb.FlamegraphNodeMeta{Other: true}, Cumulative: 10, Diff: 1},
+			),
+			trimTestNode("b", 30, trimTestNode("b1", 30)),
+			{Meta: &pb.FlamegraphNodeMeta{Other: true}, Cumulative: 10, Diff: 0},
+		}, fg.Root.Children)
+	})
+}
+
+func TestQueryRequestNodeTrimThreshold(t *testing.T) {
+	t.Parallel()
+
+	req := &pb.QueryRequest{
+		Mode: pb.QueryRequest_MODE_SINGLE_UNSPECIFIED,
+		Options: &pb.QueryRequest_Single{
+			Single: &pb.SingleProfile{
+				Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+				Time:  timestamppb.Now(),
+			},
+		},
+		NodeTrimThreshold: 1.5,
+	}
+	require.Error(t, req.Validate())
+
+	req.NodeTrimThreshold = 0.01
+	require.NoError(t, req.Validate())
+}
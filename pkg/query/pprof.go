@@ -152,9 +152,27 @@ func GenerateFlatPprof(ctx context.Context, ip *parcaprofile.Profile) (*profile.
 			s.Value = s.DiffValue
 		}
 
+		var labels map[string][]string
+		if len(s.Label) > 0 {
+			labels = make(map[string][]string, len(s.Label))
+			for name, value := range s.Label {
+				labels[name] = []string{value}
+			}
+		}
+
+		var numLabels map[string][]int64
+		if len(s.NumLabel) > 0 {
+			numLabels = make(map[string][]int64, len(s.NumLabel))
+			for name, value := range s.NumLabel {
+				numLabels[name] = []int64{value}
+			}
+		}
+
 		p.Sample = append(p.Sample, &profile.Sample{
 			Value:    []int64{s.Value},
 			Location: locations,
+			Label:    labels,
+			NumLabel: numLabels,
 		})
 	}
 
@@ -96,6 +96,7 @@ func Benchmark_Query_Merge(b *testing.B) {
 				tracer,
 				m,
 				getShareServerConn(b),
+				nil,
 				query.NewEngine(
 					memory.NewGoAllocator(),
 					colDB.TableProvider(),
@@ -0,0 +1,126 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// GenerateSandwich generates the callers and callees flame graphs of the
+// function selected by the reference. Only samples with the function on their
+// stack are part of the flame graphs. The callers are the stack from the
+// innermost call of the function to the root, inverted, and the callees are
+// the stack from the outermost call of the function to the leaf, so recursive
+// calls of the function are part of both. Functions inlined into each other
+// are separate frames of the flame graphs. The samples of both flame graphs
+// have no pprof labels, so the flame graphs are not grouped by them.
+func GenerateSandwich(ctx context.Context, tracer trace.Tracer, p *profile.Profile, ref *pb.SourceReference) (*pb.Sandwich, error) {
+	callers := &profile.Profile{Meta: p.Meta}
+	callees := &profile.Profile{Meta: p.Meta}
+
+	for _, s := range p.Samples {
+		stack := stackLines(s.Locations)
+		inner, outer, ok := findFunctionCalls(stack, ref)
+		if !ok {
+			continue
+		}
+
+		callerLocations := make([]*profile.Location, len(stack)-inner)
+		for i, location := range stack[inner:] {
+			callerLocations[len(callerLocations)-1-i] = location
+		}
+		callers.Samples = append(callers.Samples, &profile.SymbolizedSample{
+			Locations: callerLocations,
+			Value:     s.Value,
+			DiffValue: s.DiffValue,
+		})
+
+		callees.Samples = append(callees.Samples, &profile.SymbolizedSample{
+			Locations: stack[:outer+1],
+			Value:     s.Value,
+			DiffValue: s.DiffValue,
+		})
+	}
+
+	callersFlamegraph, err := GenerateFlamegraphFlat(ctx, tracer, callers, nil)
+	if err != nil {
+		return nil, fmt.Errorf("generate callers flamegraph: %w", err)
+	}
+
+	calleesFlamegraph, err := GenerateFlamegraphFlat(ctx, tracer, callees, nil)
+	if err != nil {
+		return nil, fmt.Errorf("generate callees flamegraph: %w", err)
+	}
+
+	return &pb.Sandwich{
+		Callers: callersFlamegraph,
+		Callees: calleesFlamegraph,
+	}, nil
+}
+
+// stackLines returns the stack with a location for each line of the
+// locations, so that functions inlined into each other are separate frames.
+// Like in pprof, locations are ordered from the leaf to the root and their
+// inlined lines from the innermost to the outermost, so the returned stack is
+// ordered from the leaf to the root.
+func stackLines(locations []*profile.Location) []*profile.Location {
+	res := make([]*profile.Location, 0, len(locations))
+	for _, location := range locations {
+		if len(location.Lines) <= 1 {
+			res = append(res, location)
+			continue
+		}
+
+		for _, line := range location.Lines {
+			res = append(res, withLines(location, []profile.LocationLine{line}))
+		}
+	}
+
+	return res
+}
+
+// findFunctionCalls returns the indexes of the innermost and outermost call of
+// the function in the stack of single line locations.
+func findFunctionCalls(stack []*profile.Location, ref *pb.SourceReference) (inner, outer int, ok bool) {
+	for i, location := range stack {
+		if len(location.Lines) == 0 || !sourceReferenceMatches(ref, location.Lines[0]) {
+			continue
+		}
+
+		if !ok {
+			inner = i
+			ok = true
+		}
+		outer = i
+	}
+
+	return inner, outer, ok
+}
+
+// withLines returns a copy of the location with the given lines.
+func withLines(location *profile.Location, lines []profile.LocationLine) *profile.Location {
+	return &profile.Location{
+		ID:       location.ID,
+		Address:  location.Address,
+		IsFolded: location.IsFolded,
+		Mapping:  location.Mapping,
+		Lines:    lines,
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+// sandwichChild returns the child node of the function with the given name.
+func sandwichChild(t *testing.T, children []*pb.FlamegraphNode, name string) *pb.FlamegraphNode {
+	t.Helper()
+
+	for _, child := range children {
+		if child.Meta.Function.Name == name {
+			return child
+		}
+	}
+	require.Failf(t, "child not found", "no child of function %q", name)
+	return nil
+}
+
+func TestGenerateSandwich(t *testing.T) {
+	t.Parallel()
+
+	newLocation := func(id string, names ...string) *profile.Location {
+		location := &profile.Location{ID: id}
+		for _, name := range names {
+			location.Lines = append(location.Lines, profile.LocationLine{
+				Function: &metastorev1alpha1.Function{Id: name, Name: name},
+			})
+		}
+		return location
+	}
+
+	mallocgc := newLocation("1", "mallocgc")
+	foo := newLocation("2", "foo")
+	bar := newLocation("3", "bar")
+	main := newLocation("4", "main")
+	x := newLocation("5", "x")
+	y := newLocation("6", "y")
+	// Inlined lines are ordered from the innermost to the outermost, mallocgc
+	// is inlined into wrapper and memmove into mallocgc.
+	wrapper := newLocation("7", "mallocgc", "wrapper")
+	inlined := newLocation("8", "memmove", "mallocgc", "wrapper")
+
+	p := &profile.Profile{
+		Meta: profile.Meta{SampleType: profile.ValueType{Type: "alloc_space", Unit: "bytes"}},
+		Samples: []*profile.SymbolizedSample{{
+			Locations: []*profile.Location{mallocgc, foo, main},
+			Value:     3,
+		}, {
+			Locations: []*profile.Location{mallocgc, bar, main},
+			Value:     2,
+			DiffValue: 1,
+		}, {
+			// Samples without the function are not part of the sandwich.
+			Locations: []*profile.Location{foo, main},
+			Value:     1,
+		}, {
+			Locations: []*profile.Location{x, mallocgc, y, mallocgc, main},
+			Value:     4,
+		}, {
+			Locations: []*profile.Location{wrapper, main},
+			Value:     1,
+		}, {
+			Locations: []*profile.Location{inlined, main},
+			Value:     2,
+		}},
+	}
+
+	sandwich, err := GenerateSandwich(
+		context.Background(),
+		trace.NewNoopTracerProvider().Tracer(""),
+		p,
+		&pb.SourceReference{Function: "mallocgc"},
+	)
+	require.NoError(t, err)
+
+	// The callers are inverted, the function is the root.
+	callers := sandwich.Callers
+	require.Equal(t, int64(12), callers.Total)
+	require.Equal(t, "bytes", callers.Unit)
+	require.Len(t, callers.Root.Children, 1)
+
+	root := callers.Root.Children[0]
+	require.Equal(t, "mallocgc", root.Meta.Function.Name)
+	require.Equal(t, int64(12), root.Cumulative)
+	require.Equal(t, int64(1), root.Diff)
+	require.Len(t, root.Children, 4)
+
+	fooCaller := sandwichChild(t, root.Children, "foo")
+	require.Equal(t, int64(3), fooCaller.Cumulative)
+	require.Equal(t, int64(3), sandwichChild(t, fooCaller.Children, "main").Cumulative)
+
+	barCaller := sandwichChild(t, root.Children, "bar")
+	require.Equal(t, int64(2), barCaller.Cumulative)
+	require.Equal(t, int64(1), barCaller.Diff)
+
+	// The innermost call is the root, the recursive call is one of its callers.
+	yCaller := sandwichChild(t, root.Children, "y")
+	require.Equal(t, int64(4), yCaller.Cumulative)
+	recursiveCaller := sandwichChild(t, yCaller.Children, "mallocgc")
+	require.Equal(t, int64(4), sandwichChild(t, recursiveCaller.Children, "main").Cumulative)
+
+	// Functions inlined into the function are not its callers.
+	wrapperCaller := sandwichChild(t, root.Children, "wrapper")
+	require.Equal(t, int64(3), wrapperCaller.Cumulative)
+	require.Equal(t, int64(3), sandwichChild(t, wrapperCaller.Children, "main").Cumulative)
+
+	// The callees start at the outermost call of the function.
+	callees := sandwich.Callees
+	require.Equal(t, int64(12), callees.Total)
+	require.Len(t, callees.Root.Children, 1)
+
+	root = callees.Root.Children[0]
+	require.Equal(t, "mallocgc", root.Meta.Function.Name)
+	require.Equal(t, int64(12), root.Cumulative)
+	require.Len(t, root.Children, 2)
+
+	// Functions inlined into the function are its callees.
+	require.Equal(t, int64(2), sandwichChild(t, root.Children, "memmove").Cumulative)
+
+	yCallee := sandwichChild(t, root.Children, "y")
+	require.Equal(t, int64(4), yCallee.Cumulative)
+	recursiveCallee := sandwichChild(t, yCallee.Children, "mallocgc")
+	require.Equal(t, int64(4), sandwichChild(t, recursiveCallee.Children, "x").Cumulative)
+}
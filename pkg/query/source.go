@@ -0,0 +1,115 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	parcaprofile "github.com/parca-dev/parca/pkg/profile"
+)
+
+type sourceLineKey struct {
+	filename string
+	line     int64
+}
+
+// GenerateSource generates the per line values of the source files selected by
+// the reference, like `pprof -list` does. Values of lines found multiple times
+// in the stack of a sample are only counted once.
+func GenerateSource(ctx context.Context, p *parcaprofile.Profile, ref *pb.SourceReference) (*pb.Source, error) {
+	lines := map[sourceLineKey]*pb.SourceLine{}
+	var cumulative int64
+
+	for _, s := range p.Samples {
+		cumulative += s.Value
+
+		seen := map[sourceLineKey]struct{}{}
+		for i, location := range s.Locations {
+			for j, line := range location.Lines {
+				if !sourceReferenceMatches(ref, line) {
+					continue
+				}
+
+				key := sourceLineKey{filename: line.Function.GetFilename(), line: line.Line}
+				sl, ok := lines[key]
+				if !ok {
+					sl = &pb.SourceLine{Line: line.Line}
+					lines[key] = sl
+				}
+
+				// Inlined lines are ordered from the innermost to the
+				// outermost, so the first line of the first location is the
+				// leaf.
+				if i == 0 && j == 0 {
+					sl.Flat += s.Value
+					sl.FlatDiff += s.DiffValue
+				}
+
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				sl.Cumulative += s.Value
+				sl.Diff += s.DiffValue
+			}
+		}
+	}
+
+	files := map[string]*pb.SourceFile{}
+	for key, line := range lines {
+		file, ok := files[key.filename]
+		if !ok {
+			file = &pb.SourceFile{Filename: key.filename}
+			files[key.filename] = file
+		}
+		file.Lines = append(file.Lines, line)
+	}
+
+	source := &pb.Source{
+		Files:      make([]*pb.SourceFile, 0, len(files)),
+		Cumulative: cumulative,
+		Unit:       p.Meta.SampleType.Unit,
+	}
+	for _, file := range files {
+		sort.Slice(file.Lines, func(i, j int) bool {
+			return file.Lines[i].Line < file.Lines[j].Line
+		})
+		source.Files = append(source.Files, file)
+	}
+	sort.Slice(source.Files, func(i, j int) bool {
+		return source.Files[i].Filename < source.Files[j].Filename
+	})
+
+	return source, nil
+}
+
+// sourceReferenceMatches returns whether the line matches all of the set fields
+// of the reference.
+func sourceReferenceMatches(ref *pb.SourceReference, line parcaprofile.LocationLine) bool {
+	if ref.GetFunction() != "" && line.Function.GetName() != ref.GetFunction() {
+		return false
+	}
+
+	if ref.GetFilename() != "" {
+		filename := line.Function.GetFilename()
+		if filename != ref.GetFilename() && !strings.HasSuffix(filename, "/"+ref.GetFilename()) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	metastorev1alpha1 "github.com/parca-dev/parca/gen/proto/go/parca/metastore/v1alpha1"
+	pb "github.com/parca-dev/parca/gen/proto/go/parca/query/v1alpha1"
+	"github.com/parca-dev/parca/pkg/profile"
+)
+
+func TestGenerateSource(t *testing.T) {
+	t.Parallel()
+
+	mainFn := &metastorev1alpha1.Function{Id: "1", Name: "main", Filename: "/src/app/main.go"}
+	fooFn := &metastorev1alpha1.Function{Id: "2", Name: "foo", Filename: "/src/app/foo.go"}
+	newLocation := func(id string, fn *metastorev1alpha1.Function, line int64) *profile.Location {
+		return &profile.Location{
+			ID:    id,
+			Lines: []profile.LocationLine{{Line: line, Function: fn}},
+		}
+	}
+
+	main10 := newLocation("1", mainFn, 10)
+	main12 := newLocation("2", mainFn, 12)
+	foo5 := newLocation("3", fooFn, 5)
+
+	p := &profile.Profile{
+		Meta: profile.Meta{SampleType: profile.ValueType{Type: "cpu", Unit: "nanoseconds"}},
+		Samples: []*profile.SymbolizedSample{{
+			Locations: []*profile.Location{foo5, main10},
+			Value:     3,
+			DiffValue: 1,
+		}, {
+			Locations: []*profile.Location{main12, main10},
+			Value:     2,
+		}, {
+			// The recursion is only counted once.
+			Locations: []*profile.Location{main10, main10},
+			Value:     1,
+			DiffValue: -1,
+		}},
+	}
+
+	source, err := GenerateSource(context.Background(), p, &pb.SourceReference{Function: "main"})
+	require.NoError(t, err)
+	require.Equal(t, &pb.Source{
+		Files: []*pb.SourceFile{{
+			Filename: "/src/app/main.go",
+			Lines: []*pb.SourceLine{
+				{Line: 10, Flat: 1, Cumulative: 6, Diff: 0, FlatDiff: -1},
+				{Line: 12, Flat: 2, Cumulative: 2},
+			},
+		}},
+		Cumulative: 6,
+		Unit:       "nanoseconds",
+	}, source)
+
+	source, err = GenerateSource(context.Background(), p, &pb.SourceReference{Filename: "app/foo.go"})
+	require.NoError(t, err)
+	require.Equal(t, []*pb.SourceFile{{
+		Filename: "/src/app/foo.go",
+		Lines:    []*pb.SourceLine{{Line: 5, Flat: 3, Cumulative: 3, Diff: 1, FlatDiff: 1}},
+	}}, source.Files)
+
+	// A filename only matches whole path elements.
+	source, err = GenerateSource(context.Background(), p, &pb.SourceReference{Filename: "oo.go"})
+	require.NoError(t, err)
+	require.Len(t, source.Files, 0)
+
+	source, err = GenerateSource(context.Background(), p, &pb.SourceReference{Function: "main", Filename: "foo.go"})
+	require.NoError(t, err)
+	require.Len(t, source.Files, 0)
+}
+
+func TestGenerateSourceInlined(t *testing.T) {
+	t.Parallel()
+
+	mainFn := &metastorev1alpha1.Function{Id: "1", Name: "main", Filename: "/src/app/main.go"}
+	inlinedFn := &metastorev1alpha1.Function{Id: "2", Name: "inlined", Filename: "/src/app/main.go"}
+
+	p := &profile.Profile{
+		Samples: []*profile.SymbolizedSample{{
+			Locations: []*profile.Location{{
+				ID: "1",
+				Lines: []profile.LocationLine{
+					{Line: 20, Function: inlinedFn},
+					{Line: 10, Function: mainFn},
+				},
+			}},
+			Value: 4,
+		}},
+	}
+
+	source, err := GenerateSource(context.Background(), p, &pb.SourceReference{Filename: "main.go"})
+	require.NoError(t, err)
+	require.Equal(t, []*pb.SourceFile{{
+		Filename: "/src/app/main.go",
+		Lines: []*pb.SourceLine{
+			{Line: 10, Cumulative: 4},
+			{Line: 20, Flat: 4, Cumulative: 4},
+		},
+	}}, source.Files)
+}
+
+func TestQueryRequestSourceReferenceRequired(t *testing.T) {
+	t.Parallel()
+
+	req := &pb.QueryRequest{
+		Mode:       pb.QueryRequest_MODE_SINGLE_UNSPECIFIED,
+		ReportType: pb.QueryRequest_REPORT_TYPE_SOURCE,
+		Options: &pb.QueryRequest_Single{
+			Single: &pb.SingleProfile{
+				Query: `memory:alloc_objects:count:space:bytes{job="default"}`,
+				Time:  timestamppb.Now(),
+			},
+		},
+	}
+	require.Error(t, req.Validate())
+
+	req.SourceReference = &pb.SourceReference{Function: "main"}
+	require.NoError(t, req.Validate())
+}
@@ -105,6 +105,7 @@ func newScrapePool(
 			t,
 			s,
 			log.With(logger, "target", t),
+			sp.config.Tenant,
 			externalLabels,
 			sp.metrics.targetIntervalLength,
 			buffers,
@@ -359,6 +360,7 @@ type scrapeLoop struct {
 	l              log.Logger
 	intervalLength *prometheus.SummaryVec
 	lastScrapeSize int
+	tenant         string
 	externalLabels labels.Labels
 
 	buffers *pool.Pool
@@ -374,6 +376,7 @@ func newScrapeLoop(ctx context.Context,
 	t *Target,
 	sc scraper,
 	l log.Logger,
+	tenant string,
 	externalLabels labels.Labels,
 	targetIntervalLength *prometheus.SummaryVec,
 	buffers *pool.Pool,
@@ -392,6 +395,7 @@ func newScrapeLoop(ctx context.Context,
 		store:          store,
 		stopped:        make(chan struct{}),
 		l:              l,
+		tenant:         tenant,
 		externalLabels: externalLabels,
 		intervalLength: targetIntervalLength,
 		ctx:            ctx,
@@ -482,7 +486,7 @@ mainLoop:
 			}
 
 			_, err := sl.store.WriteRaw(sl.ctx, &profilepb.WriteRawRequest{
-				Tenant: "",
+				Tenant: sl.tenant,
 				Series: []*profilepb.RawProfileSeries{
 					{
 						Labels: protolbls,
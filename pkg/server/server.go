@@ -50,6 +50,7 @@ import (
 
 	"github.com/parca-dev/parca/pkg/debuginfo"
 	"github.com/parca-dev/parca/pkg/prober"
+	"github.com/parca-dev/parca/pkg/tenant"
 	"github.com/parca-dev/parca/ui"
 )
 
@@ -78,6 +79,13 @@ type Server struct {
 	grpcProbe *prober.GRPCProbe
 	reg       *prometheus.Registry
 	version   string
+
+	handlers []handler
+}
+
+type handler struct {
+	pattern string
+	handler http.Handler
 }
 
 func NewServer(reg *prometheus.Registry, version string) *Server {
@@ -88,6 +96,12 @@ func NewServer(reg *prometheus.Registry, version string) *Server {
 	}
 }
 
+// Handle registers an HTTP handler for the pattern, served next to the gRPC
+// gateway. It must be called before ListenAndServe.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.handlers = append(s.handlers, handler{pattern: pattern, handler: h})
+}
+
 // ListenAndServe starts the http grpc gateway server.
 func (s *Server) ListenAndServe(ctx context.Context, logger log.Logger, port string, allowedCORSOrigins []string, pathPrefix string, registerables ...Registerable) error {
 	level.Info(logger).Log("msg", "starting server", "addr", port)
@@ -121,19 +135,23 @@ func (s *Server) ListenAndServe(ctx context.Context, logger log.Logger, port str
 				otelgrpc.StreamServerInterceptor(),
 				met.StreamServerInterceptor(),
 				grpc_logging.StreamServerInterceptor(kit.InterceptorLogger(logger), logOpts...),
+				tenant.StreamServerInterceptor(),
 			)),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				otelgrpc.UnaryServerInterceptor(),
 				met.UnaryServerInterceptor(),
 				grpc_logging.UnaryServerInterceptor(kit.InterceptorLogger(logger), logOpts...),
+				tenant.UnaryServerInterceptor(),
 			),
 		),
 	)
 
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-	grpcWebMux := runtime.NewServeMux()
+	grpcWebMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+	)
 	for _, r := range registerables {
 		if err := r.Register(ctx, srv, grpcWebMux, port, opts); err != nil {
 			return err
@@ -144,6 +162,9 @@ func (s *Server) ListenAndServe(ctx context.Context, logger log.Logger, port str
 
 	internalMux := chi.NewRouter()
 	internalMux.Mount("/api", grpcWebMux)
+	for _, h := range s.handlers {
+		internalMux.Handle(h.pattern, h.handler)
+	}
 
 	internalMux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
 		promhttp.HandlerFor(s.reg, promhttp.HandlerOpts{}).ServeHTTP(w, r)
@@ -278,6 +299,16 @@ func (s *Server) uiHandler(uiFS fs.FS, pathPrefix string) (*http.ServeMux, error
 	return &uiHandler, nil
 }
 
+// incomingHeaderMatcher forwards the tenant header of HTTP requests to the
+// gRPC metadata, in addition to the headers forwarded by default.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, tenant.Header) {
+		return tenant.Header, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func grpcHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler, allowedCORSOrigins []string) http.Handler {
 	allowAll := false
 	if len(allowedCORSOrigins) == 1 && allowedCORSOrigins[0] == "*" {
@@ -0,0 +1,48 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncomingHeaderMatcher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{header: "Parca-Tenant", want: "parca-tenant", ok: true},
+		{header: "parca-tenant", want: "parca-tenant", ok: true},
+		{header: "PARCA-TENANT", want: "parca-tenant", ok: true},
+		{header: "Authorization", want: "grpcgateway-Authorization", ok: true},
+		{header: "X-Parca-Tenant", ok: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := incomingHeaderMatcher(tt.header)
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
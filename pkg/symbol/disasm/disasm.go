@@ -0,0 +1,192 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disasm
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/arch/arm64/arm64asm"
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// ErrNoCode is returned if the object file does not contain the code of a
+// function, which is the case for separate debug info files.
+var ErrNoCode = errors.New("object file does not contain code")
+
+// Function is a disassembled function of an object file.
+type Function struct {
+	Name         string
+	Start        uint64
+	End          uint64
+	Instructions []Instruction
+}
+
+// Instruction is a decoded machine instruction.
+type Instruction struct {
+	Address uint64
+	Size    int
+	Text    string
+}
+
+// Contains reports whether the address is within the instruction.
+func (i Instruction) Contains(addr uint64) bool {
+	return addr >= i.Address && addr < i.Address+uint64(i.Size)
+}
+
+// Disassemble disassembles the functions of the object file at path that
+// contain any of the given addresses. Only x86-64 and arm64 are supported.
+func Disassemble(path string, addrs []uint64) ([]Function, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open elf: %w", err)
+	}
+	defer f.Close()
+
+	var decode func(code []byte, pc uint64, symname x86asm.SymLookup) (int, string)
+	switch f.Machine {
+	case elf.EM_X86_64:
+		decode = decodeX86
+	case elf.EM_AARCH64:
+		decode = decodeARM64
+	default:
+		return nil, fmt.Errorf("unsupported machine %s", f.Machine)
+	}
+
+	symbols := functionSymbols(f)
+	symname := func(addr uint64) (string, uint64) {
+		if sym, ok := findSymbol(symbols, addr); ok {
+			return sym.Name, sym.Value
+		}
+		return "", 0
+	}
+
+	seen := map[uint64]struct{}{}
+	funcs := []Function{}
+	for _, addr := range addrs {
+		sym, ok := findSymbol(symbols, addr)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[sym.Value]; ok {
+			continue
+		}
+		seen[sym.Value] = struct{}{}
+
+		code, err := symbolCode(f, sym)
+		if err != nil {
+			return nil, fmt.Errorf("read code of %s: %w", sym.Name, err)
+		}
+
+		fn := Function{
+			Name:  sym.Name,
+			Start: sym.Value,
+			End:   sym.Value + sym.Size,
+		}
+		for pc := sym.Value; len(code) > 0; {
+			size, text := decode(code, pc, symname)
+			if size > len(code) {
+				size = len(code)
+			}
+			fn.Instructions = append(fn.Instructions, Instruction{
+				Address: pc,
+				Size:    size,
+				Text:    text,
+			})
+			code = code[size:]
+			pc += uint64(size)
+		}
+		funcs = append(funcs, fn)
+	}
+
+	sort.Slice(funcs, func(i, j int) bool {
+		return funcs[i].Start < funcs[j].Start
+	})
+
+	return funcs, nil
+}
+
+func decodeX86(code []byte, pc uint64, symname x86asm.SymLookup) (int, string) {
+	inst, err := x86asm.Decode(code, 64)
+	if err != nil || inst.Len == 0 {
+		return 1, "?"
+	}
+	return inst.Len, x86asm.GNUSyntax(inst, pc, symname)
+}
+
+func decodeARM64(code []byte, _ uint64, _ x86asm.SymLookup) (int, string) {
+	inst, err := arm64asm.Decode(code)
+	if err != nil {
+		return 4, "?"
+	}
+	return 4, arm64asm.GNUSyntax(inst)
+}
+
+// functionSymbols returns the function symbols of the object file sorted by
+// their address.
+func functionSymbols(f *elf.File) []elf.Symbol {
+	// Either of the symbol tables may be missing.
+	symtab, _ := f.Symbols()
+	dynsym, _ := f.DynamicSymbols()
+
+	symbols := make([]elf.Symbol, 0, len(symtab)+len(dynsym))
+	for _, sym := range append(symtab, dynsym...) {
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC && sym.Size > 0 {
+			symbols = append(symbols, sym)
+		}
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].Value < symbols[j].Value
+	})
+
+	return symbols
+}
+
+// findSymbol returns the symbol containing the address.
+func findSymbol(symbols []elf.Symbol, addr uint64) (elf.Symbol, bool) {
+	i := sort.Search(len(symbols), func(i int) bool {
+		return symbols[i].Value > addr
+	})
+	if i == 0 {
+		return elf.Symbol{}, false
+	}
+
+	sym := symbols[i-1]
+	if addr >= sym.Value+sym.Size {
+		return elf.Symbol{}, false
+	}
+	return sym, true
+}
+
+// symbolCode returns the machine code of the symbol.
+func symbolCode(f *elf.File, sym elf.Symbol) ([]byte, error) {
+	for _, s := range f.Sections {
+		if s.Flags&elf.SHF_EXECINSTR == 0 || sym.Value < s.Addr || sym.Value+sym.Size > s.Addr+s.Size {
+			continue
+		}
+		if s.Type == elf.SHT_NOBITS {
+			return nil, ErrNoCode
+		}
+
+		code := make([]byte, sym.Size)
+		if _, err := s.ReadAt(code, int64(sym.Value-s.Addr)); err != nil {
+			return nil, err
+		}
+		return code, nil
+	}
+
+	return nil, ErrNoCode
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disasm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBinary = "../../symbolizer/testdata/2d6912fd3dd64542f6f6294f4bf9cb6c265b3085/debuginfo"
+
+func TestDisassemble(t *testing.T) {
+	t.Parallel()
+
+	// Both addresses are in main.main, an address outside of any function is
+	// ignored.
+	funcs, err := Disassemble(testBinary, []uint64{0x463781, 0x463720, 0x1})
+	require.NoError(t, err)
+	require.Len(t, funcs, 1)
+
+	fn := funcs[0]
+	require.Equal(t, "main.main", fn.Name)
+	require.Equal(t, uint64(0x463720), fn.Start)
+	require.Greater(t, fn.End, uint64(0x463781))
+	require.NotEmpty(t, fn.Instructions)
+
+	// The instructions cover the function without gaps.
+	next := fn.Start
+	found := false
+	for _, inst := range fn.Instructions {
+		require.Equal(t, next, inst.Address)
+		require.NotEmpty(t, inst.Text)
+		next += uint64(inst.Size)
+		if inst.Contains(0x463781) {
+			found = true
+		}
+	}
+	require.Equal(t, fn.End, next)
+	require.True(t, found)
+}
@@ -29,12 +29,14 @@ import (
 	"github.com/parca-dev/parca/pkg/profile"
 	"github.com/parca-dev/parca/pkg/runutil"
 	"github.com/parca-dev/parca/pkg/symbol"
+	"github.com/parca-dev/parca/pkg/tenant"
 )
 
 type Symbolizer struct {
 	logger log.Logger
 
 	metastore  pb.MetastoreServiceClient
+	tenants    TenantLister
 	symbolizer *symbol.Symbolizer
 	debuginfo  DebugInfoFetcher
 
@@ -45,15 +47,23 @@ type Symbolizer struct {
 	debuginfoCacheDir  string
 }
 
+// TenantLister lists the tenants whose locations are symbolized.
+type TenantLister interface {
+	Tenants(ctx context.Context) ([]string, error)
+}
+
 type DebugInfoFetcher interface {
 	// Fetch ensures that the debug info for the given build ID is available on
 	// a local filesystem and returns a path to it.
 	FetchDebugInfo(ctx context.Context, buildID string) (string, debuginfopb.DownloadInfo_Source, error)
 }
 
+// New returns a new Symbolizer. If tenants is nil, only the locations of the
+// default tenant are symbolized.
 func New(
 	logger log.Logger,
 	metastore pb.MetastoreServiceClient,
+	tenants TenantLister,
 	debuginfo DebugInfoFetcher,
 	symbolizer *symbol.Symbolizer,
 	debuginfodCacheDir string,
@@ -62,6 +72,7 @@ func New(
 	return &Symbolizer{
 		logger:             log.With(logger, "component", "symbolizer"),
 		metastore:          metastore,
+		tenants:            tenants,
 		symbolizer:         symbolizer,
 		debuginfo:          debuginfo,
 		debuginfodCacheDir: debuginfodCacheDir,
@@ -72,29 +83,46 @@ func New(
 func (s *Symbolizer) Run(ctx context.Context, interval time.Duration) error {
 	return runutil.Repeat(interval, ctx.Done(), func() error {
 		level.Debug(s.logger).Log("msg", "start symbolization cycle")
-		lres, err := s.metastore.UnsymbolizedLocations(ctx, &pb.UnsymbolizedLocationsRequest{})
-		if err != nil {
-			level.Error(s.logger).Log("msg", "failed to fetch unsymbolized locations", "err", err)
-			// Try again on the next cycle.
-			return nil
-		}
-		if len(lres.Locations) == 0 {
-			level.Debug(s.logger).Log("msg", "no locations to symbolize")
-			// Nothing to symbolize.
-			return nil
+		tenants := []string{""}
+		if s.tenants != nil {
+			var err error
+			tenants, err = s.tenants.Tenants(ctx)
+			if err != nil {
+				level.Error(s.logger).Log("msg", "failed to list tenants", "err", err)
+				// Try again on the next cycle.
+				return nil
+			}
 		}
 
-		level.Debug(s.logger).Log("msg", "attempting to symbolize locations", "count", len(lres.Locations))
-		err = s.symbolize(ctx, lres.Locations)
-		if err != nil {
-			level.Warn(s.logger).Log("msg", "symbolization attempt finished with errors")
-			level.Debug(s.logger).Log("msg", "errors occurred during symbolization", "err", err)
+		for _, t := range tenants {
+			s.runTenant(tenant.NewContext(ctx, t), t)
 		}
 		level.Debug(s.logger).Log("msg", "symbolization loop completed")
 		return nil
 	})
 }
 
+func (s *Symbolizer) runTenant(ctx context.Context, t string) {
+	lres, err := s.metastore.UnsymbolizedLocations(ctx, &pb.UnsymbolizedLocationsRequest{})
+	if err != nil {
+		level.Error(s.logger).Log("msg", "failed to fetch unsymbolized locations", "tenant", t, "err", err)
+		// Try again on the next cycle.
+		return
+	}
+	if len(lres.Locations) == 0 {
+		level.Debug(s.logger).Log("msg", "no locations to symbolize", "tenant", t)
+		// Nothing to symbolize.
+		return
+	}
+
+	level.Debug(s.logger).Log("msg", "attempting to symbolize locations", "tenant", t, "count", len(lres.Locations))
+	err = s.symbolize(ctx, lres.Locations)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "symbolization attempt finished with errors", "tenant", t)
+		level.Debug(s.logger).Log("msg", "errors occurred during symbolization", "tenant", t, "err", err)
+	}
+}
+
 // UnsymbolizableMapping returns true if a mapping points to a binary for which
 // locations can't be symbolized in principle, at least now. Examples are
 // "[vdso]", [vsyscall]" and some others, see the code.
@@ -490,6 +490,9 @@ func setup(t *testing.T) (*grpc.ClientConn, pb.MetastoreServiceClient, *Symboliz
 		metastore,
 		table,
 		false,
+		nil,
+		1,
+		0,
 	)
 
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
@@ -520,6 +523,7 @@ func setup(t *testing.T) (*grpc.ClientConn, pb.MetastoreServiceClient, *Symboliz
 	return conn, metastore, New(
 		logger,
 		metastore,
+		nil,
 		dbgStr,
 		sym,
 		symbolizerCacheDir,
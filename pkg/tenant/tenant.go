@@ -0,0 +1,114 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant carries the tenant a request is made for through its
+// context. Data of each tenant is stored and queried in isolation. The empty
+// tenant is the default tenant, used when a request does not specify one.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Header is the gRPC metadata key, and HTTP header, that selects the tenant
+// of a request.
+const Header = "parca-tenant"
+
+// The tenant is used as part of storage keys, so it is restricted to a safe
+// set of characters.
+var validTenant = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,128}$`)
+
+type contextKey struct{}
+
+// NewContext returns a new context carrying the tenant.
+func NewContext(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// FromContext returns the tenant carried by the context, or the default
+// tenant if there is none.
+func FromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(contextKey{}).(string)
+	return tenant
+}
+
+// Validate returns an error if the tenant name is invalid.
+func Validate(tenant string) error {
+	if tenant == "" {
+		return nil
+	}
+
+	if !validTenant.MatchString(tenant) {
+		return fmt.Errorf("invalid tenant %q: must match %s", tenant, validTenant.String())
+	}
+
+	return nil
+}
+
+func fromMetadata(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	values := md.Get(Header)
+	switch len(values) {
+	case 0:
+		return ctx, nil
+	case 1:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "expected at most one %s header, got %d", Header, len(values))
+	}
+
+	if err := Validate(values[0]); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return NewContext(ctx, values[0]), nil
+}
+
+// UnaryServerInterceptor returns an interceptor that reads the tenant from the
+// incoming metadata into the context of the request.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := fromMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that reads the tenant from
+// the incoming metadata into the context of the stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := fromMetadata(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 The Parca Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{{
+		name: "none",
+		ctx:  context.Background(),
+		want: "",
+	}, {
+		name: "tenant",
+		ctx:  NewContext(context.Background(), "a"),
+		want: "a",
+	}, {
+		name: "overridden",
+		ctx:  NewContext(NewContext(context.Background(), "a"), "b"),
+		want: "b",
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, FromContext(tt.ctx))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tenant  string
+		wantErr bool
+	}{
+		{name: "default", tenant: ""},
+		{name: "alphanumeric", tenant: "Team42"},
+		{name: "punctuation", tenant: "team_a.prod-1"},
+		{name: "max length", tenant: strings.Repeat("a", 128)},
+		{name: "too long", tenant: strings.Repeat("a", 129), wantErr: true},
+		{name: "slash", tenant: "a/b", wantErr: true},
+		{name: "space", tenant: "a b", wantErr: true},
+		{name: "separator", tenant: "a\x00b", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(tt.tenant)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		want     string
+		wantCode codes.Code
+	}{{
+		name: "no metadata",
+		want: "",
+	}, {
+		name: "no header",
+		md:   metadata.Pairs("other", "a"),
+		want: "",
+	}, {
+		name: "header",
+		md:   metadata.Pairs(Header, "a"),
+		want: "a",
+	}, {
+		name: "header case insensitive",
+		md:   metadata.Pairs("Parca-Tenant", "a"),
+		want: "a",
+	}, {
+		name:     "multiple headers",
+		md:       metadata.Pairs(Header, "a", Header, "b"),
+		wantCode: codes.InvalidArgument,
+	}, {
+		name:     "invalid tenant",
+		md:       metadata.Pairs(Header, "a/b"),
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			got := ""
+			_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				got = FromContext(ctx)
+				return nil, nil
+			})
+			if tt.wantCode != codes.OK {
+				require.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -4,6 +4,15 @@
 import type { RpcTransport } from "@protobuf-ts/runtime-rpc";
 import type { ServiceInfo } from "@protobuf-ts/runtime-rpc";
 import { ProfileStoreService } from "./profilestore";
+import type { WriteJFRResponse } from "./profilestore";
+import type { WriteJFRRequest } from "./profilestore";
+import type { WriteFoldedResponse } from "./profilestore";
+import type { WriteFoldedRequest } from "./profilestore";
+import type { WriteArrowResponse } from "./profilestore";
+import type { WriteArrowRequest } from "./profilestore";
+import type { WriteStreamResponse } from "./profilestore";
+import type { WriteStreamRequest } from "./profilestore";
+import type { ClientStreamingCall } from "@protobuf-ts/runtime-rpc";
 import { stackIntercept } from "@protobuf-ts/runtime-rpc";
 import type { WriteRawResponse } from "./profilestore";
 import type { WriteRawRequest } from "./profilestore";
@@ -21,6 +30,36 @@ export interface IProfileStoreServiceClient {
      * @generated from protobuf rpc: WriteRaw(parca.profilestore.v1alpha1.WriteRawRequest) returns (parca.profilestore.v1alpha1.WriteRawResponse);
      */
     writeRaw(input: WriteRawRequest, options?: RpcOptions): UnaryCall<WriteRawRequest, WriteRawResponse>;
+    /**
+     * WriteStream accepts a stream of raw pprof profiles, which are ingested
+     * concurrently. The stats of the stream are returned once it is closed.
+     *
+     * @generated from protobuf rpc: WriteStream(stream parca.profilestore.v1alpha1.WriteStreamRequest) returns (parca.profilestore.v1alpha1.WriteStreamResponse);
+     */
+    writeStream(options?: RpcOptions): ClientStreamingCall<WriteStreamRequest, WriteStreamResponse>;
+    /**
+     * WriteArrow accepts Arrow record batches of already normalized samples,
+     * which are inserted as they are.
+     *
+     * @generated from protobuf rpc: WriteArrow(parca.profilestore.v1alpha1.WriteArrowRequest) returns (parca.profilestore.v1alpha1.WriteArrowResponse);
+     */
+    writeArrow(input: WriteArrowRequest, options?: RpcOptions): UnaryCall<WriteArrowRequest, WriteArrowResponse>;
+    /**
+     * WriteFolded accepts stacks in the folded format, as emitted by
+     * async-profiler, py-spy or stackcollapse, which are written as samples of
+     * the given profile type.
+     *
+     * @generated from protobuf rpc: WriteFolded(parca.profilestore.v1alpha1.WriteFoldedRequest) returns (parca.profilestore.v1alpha1.WriteFoldedResponse);
+     */
+    writeFolded(input: WriteFoldedRequest, options?: RpcOptions): UnaryCall<WriteFoldedRequest, WriteFoldedResponse>;
+    /**
+     * WriteJFR accepts a Java Flight Recorder recording, whose execution
+     * samples, allocation samples and lock contention events are each written
+     * as a profile of their own.
+     *
+     * @generated from protobuf rpc: WriteJFR(parca.profilestore.v1alpha1.WriteJFRRequest) returns (parca.profilestore.v1alpha1.WriteJFRResponse);
+     */
+    writeJFR(input: WriteJFRRequest, options?: RpcOptions): UnaryCall<WriteJFRRequest, WriteJFRResponse>;
 }
 /**
  * ProfileStoreService is the service the accepts pprof writes
@@ -42,4 +81,46 @@ export class ProfileStoreServiceClient implements IProfileStoreServiceClient, Se
         const method = this.methods[0], opt = this._transport.mergeOptions(options);
         return stackIntercept<WriteRawRequest, WriteRawResponse>("unary", this._transport, method, opt, input);
     }
+    /**
+     * WriteStream accepts a stream of raw pprof profiles, which are ingested
+     * concurrently. The stats of the stream are returned once it is closed.
+     *
+     * @generated from protobuf rpc: WriteStream(stream parca.profilestore.v1alpha1.WriteStreamRequest) returns (parca.profilestore.v1alpha1.WriteStreamResponse);
+     */
+    writeStream(options?: RpcOptions): ClientStreamingCall<WriteStreamRequest, WriteStreamResponse> {
+        const method = this.methods[1], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteStreamRequest, WriteStreamResponse>("clientStreaming", this._transport, method, opt);
+    }
+    /**
+     * WriteArrow accepts Arrow record batches of already normalized samples,
+     * which are inserted as they are.
+     *
+     * @generated from protobuf rpc: WriteArrow(parca.profilestore.v1alpha1.WriteArrowRequest) returns (parca.profilestore.v1alpha1.WriteArrowResponse);
+     */
+    writeArrow(input: WriteArrowRequest, options?: RpcOptions): UnaryCall<WriteArrowRequest, WriteArrowResponse> {
+        const method = this.methods[2], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteArrowRequest, WriteArrowResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * WriteFolded accepts stacks in the folded format, as emitted by
+     * async-profiler, py-spy or stackcollapse, which are written as samples of
+     * the given profile type.
+     *
+     * @generated from protobuf rpc: WriteFolded(parca.profilestore.v1alpha1.WriteFoldedRequest) returns (parca.profilestore.v1alpha1.WriteFoldedResponse);
+     */
+    writeFolded(input: WriteFoldedRequest, options?: RpcOptions): UnaryCall<WriteFoldedRequest, WriteFoldedResponse> {
+        const method = this.methods[3], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteFoldedRequest, WriteFoldedResponse>("unary", this._transport, method, opt, input);
+    }
+    /**
+     * WriteJFR accepts a Java Flight Recorder recording, whose execution
+     * samples, allocation samples and lock contention events are each written
+     * as a profile of their own.
+     *
+     * @generated from protobuf rpc: WriteJFR(parca.profilestore.v1alpha1.WriteJFRRequest) returns (parca.profilestore.v1alpha1.WriteJFRResponse);
+     */
+    writeJFR(input: WriteJFRRequest, options?: RpcOptions): UnaryCall<WriteJFRRequest, WriteJFRResponse> {
+        const method = this.methods[4], opt = this._transport.mergeOptions(options);
+        return stackIntercept<WriteJFRRequest, WriteJFRResponse>("unary", this._transport, method, opt, input);
+    }
 }
@@ -45,6 +45,154 @@ export interface WriteRawRequest {
  */
 export interface WriteRawResponse {
 }
+/**
+ * WriteStreamRequest writes a series of pprof profiles of a stream
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteStreamRequest
+ */
+export interface WriteStreamRequest {
+    /**
+     * series is a set of raw pprof profiles and accompanying labels
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.RawProfileSeries series = 1;
+     */
+    series?: RawProfileSeries;
+    /**
+     * normalized is a flag indicating if the addresses in the profile is normalized for position independent code
+     *
+     * @generated from protobuf field: bool normalized = 2;
+     */
+    normalized: boolean;
+}
+/**
+ * WriteStreamResponse are the stats of a stream of writes
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteStreamResponse
+ */
+export interface WriteStreamResponse {
+    /**
+     * received_series is the number of series received
+     *
+     * @generated from protobuf field: uint64 received_series = 1;
+     */
+    receivedSeries: string;
+    /**
+     * ingested_series is the number of series ingested
+     *
+     * @generated from protobuf field: uint64 ingested_series = 2;
+     */
+    ingestedSeries: string;
+    /**
+     * failed_series is the number of series that failed to be ingested
+     *
+     * @generated from protobuf field: uint64 failed_series = 3;
+     */
+    failedSeries: string;
+}
+/**
+ * WriteArrowRequest writes Arrow record batches of normalized samples
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteArrowRequest
+ */
+export interface WriteArrowRequest {
+    /**
+     * record is an Arrow IPC stream of record batches matching the schema of the
+     * profile store, with stacktrace IDs obtained via the metastore API. Dynamic
+     * columns are named <column>.<name>, e.g. labels.job. The tenant is taken
+     * from the request metadata.
+     *
+     * @generated from protobuf field: bytes record = 1;
+     */
+    record: Uint8Array;
+}
+/**
+ * WriteArrowResponse is the empty response
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteArrowResponse
+ */
+export interface WriteArrowResponse {
+}
+/**
+ * WriteFoldedRequest writes stacks in the folded format
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteFoldedRequest
+ */
+export interface WriteFoldedRequest {
+    /**
+     * labels are the labels of the series the samples are written to
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labels = 1;
+     */
+    labels?: LabelSet;
+    /**
+     * profile_type is the type of the samples, of the form
+     * <name>:<sample-type>:<sample-unit>:<period-type>:<period-unit>
+     *
+     * @generated from protobuf field: string profile_type = 2;
+     */
+    profileType: string;
+    /**
+     * folded are the stacks, one per line with its frames separated by
+     * semicolons, root first, followed by a space and its value, e.g.
+     * main;foo;bar 42
+     *
+     * @generated from protobuf field: bytes folded = 3;
+     */
+    folded: Uint8Array;
+    /**
+     * timestamp is the time the samples were collected at in milliseconds since
+     * the epoch, the time of the request is used if it is not set
+     *
+     * @generated from protobuf field: int64 timestamp = 4;
+     */
+    timestamp: string;
+    /**
+     * duration is the duration in nanoseconds the samples were collected over,
+     * it is only set for delta profiles
+     *
+     * @generated from protobuf field: int64 duration = 5;
+     */
+    duration: string;
+    /**
+     * period is the period the samples were collected at, in the period unit
+     *
+     * @generated from protobuf field: int64 period = 6;
+     */
+    period: string;
+}
+/**
+ * WriteFoldedResponse is the empty response
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteFoldedResponse
+ */
+export interface WriteFoldedResponse {
+}
+/**
+ * WriteJFRRequest writes a Java Flight Recorder recording
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteJFRRequest
+ */
+export interface WriteJFRRequest {
+    /**
+     * labels are the labels of the series the profiles are written to
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labels = 1;
+     */
+    labels?: LabelSet;
+    /**
+     * jfr is the recording, of one or more chunks
+     *
+     * @generated from protobuf field: bytes jfr = 2;
+     */
+    jfr: Uint8Array;
+}
+/**
+ * WriteJFRResponse is the empty response
+ *
+ * @generated from protobuf message parca.profilestore.v1alpha1.WriteJFRResponse
+ */
+export interface WriteJFRResponse {
+}
 /**
  * RawProfileSeries represents the pprof profile and its associated labels
  *
@@ -197,6 +345,382 @@ class WriteRawResponse$Type extends MessageType<WriteRawResponse> {
  */
 export const WriteRawResponse = new WriteRawResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class WriteStreamRequest$Type extends MessageType<WriteStreamRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteStreamRequest", [
+            { no: 1, name: "series", kind: "message", T: () => RawProfileSeries },
+            { no: 2, name: "normalized", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteStreamRequest>): WriteStreamRequest {
+        const message = { normalized: false };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteStreamRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteStreamRequest): WriteStreamRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.RawProfileSeries series */ 1:
+                    message.series = RawProfileSeries.internalBinaryRead(reader, reader.uint32(), options, message.series);
+                    break;
+                case /* bool normalized */ 2:
+                    message.normalized = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteStreamRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.RawProfileSeries series = 1; */
+        if (message.series)
+            RawProfileSeries.internalBinaryWrite(message.series, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* bool normalized = 2; */
+        if (message.normalized !== false)
+            writer.tag(2, WireType.Varint).bool(message.normalized);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteStreamRequest
+ */
+export const WriteStreamRequest = new WriteStreamRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteStreamResponse$Type extends MessageType<WriteStreamResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteStreamResponse", [
+            { no: 1, name: "received_series", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "ingested_series", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 3, name: "failed_series", kind: "scalar", T: 4 /*ScalarType.UINT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteStreamResponse>): WriteStreamResponse {
+        const message = { receivedSeries: "0", ingestedSeries: "0", failedSeries: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteStreamResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteStreamResponse): WriteStreamResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* uint64 received_series */ 1:
+                    message.receivedSeries = reader.uint64().toString();
+                    break;
+                case /* uint64 ingested_series */ 2:
+                    message.ingestedSeries = reader.uint64().toString();
+                    break;
+                case /* uint64 failed_series */ 3:
+                    message.failedSeries = reader.uint64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteStreamResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 received_series = 1; */
+        if (message.receivedSeries !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.receivedSeries);
+        /* uint64 ingested_series = 2; */
+        if (message.ingestedSeries !== "0")
+            writer.tag(2, WireType.Varint).uint64(message.ingestedSeries);
+        /* uint64 failed_series = 3; */
+        if (message.failedSeries !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.failedSeries);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteStreamResponse
+ */
+export const WriteStreamResponse = new WriteStreamResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteArrowRequest$Type extends MessageType<WriteArrowRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteArrowRequest", [
+            { no: 1, name: "record", kind: "scalar", T: 12 /*ScalarType.BYTES*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteArrowRequest>): WriteArrowRequest {
+        const message = { record: new Uint8Array(0) };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteArrowRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteArrowRequest): WriteArrowRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* bytes record */ 1:
+                    message.record = reader.bytes();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteArrowRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bytes record = 1; */
+        if (message.record.length)
+            writer.tag(1, WireType.LengthDelimited).bytes(message.record);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteArrowRequest
+ */
+export const WriteArrowRequest = new WriteArrowRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteArrowResponse$Type extends MessageType<WriteArrowResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteArrowResponse", []);
+    }
+    create(value?: PartialMessage<WriteArrowResponse>): WriteArrowResponse {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteArrowResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteArrowResponse): WriteArrowResponse {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: WriteArrowResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteArrowResponse
+ */
+export const WriteArrowResponse = new WriteArrowResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteFoldedRequest$Type extends MessageType<WriteFoldedRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteFoldedRequest", [
+            { no: 1, name: "labels", kind: "message", T: () => LabelSet },
+            { no: 2, name: "profile_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "folded", kind: "scalar", T: 12 /*ScalarType.BYTES*/ },
+            { no: 4, name: "timestamp", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "duration", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "period", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteFoldedRequest>): WriteFoldedRequest {
+        const message = { profileType: "", folded: new Uint8Array(0), timestamp: "0", duration: "0", period: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteFoldedRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteFoldedRequest): WriteFoldedRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labels */ 1:
+                    message.labels = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labels);
+                    break;
+                case /* string profile_type */ 2:
+                    message.profileType = reader.string();
+                    break;
+                case /* bytes folded */ 3:
+                    message.folded = reader.bytes();
+                    break;
+                case /* int64 timestamp */ 4:
+                    message.timestamp = reader.int64().toString();
+                    break;
+                case /* int64 duration */ 5:
+                    message.duration = reader.int64().toString();
+                    break;
+                case /* int64 period */ 6:
+                    message.period = reader.int64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteFoldedRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labels = 1; */
+        if (message.labels)
+            LabelSet.internalBinaryWrite(message.labels, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string profile_type = 2; */
+        if (message.profileType !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.profileType);
+        /* bytes folded = 3; */
+        if (message.folded.length)
+            writer.tag(3, WireType.LengthDelimited).bytes(message.folded);
+        /* int64 timestamp = 4; */
+        if (message.timestamp !== "0")
+            writer.tag(4, WireType.Varint).int64(message.timestamp);
+        /* int64 duration = 5; */
+        if (message.duration !== "0")
+            writer.tag(5, WireType.Varint).int64(message.duration);
+        /* int64 period = 6; */
+        if (message.period !== "0")
+            writer.tag(6, WireType.Varint).int64(message.period);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteFoldedRequest
+ */
+export const WriteFoldedRequest = new WriteFoldedRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteFoldedResponse$Type extends MessageType<WriteFoldedResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteFoldedResponse", []);
+    }
+    create(value?: PartialMessage<WriteFoldedResponse>): WriteFoldedResponse {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteFoldedResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteFoldedResponse): WriteFoldedResponse {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: WriteFoldedResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteFoldedResponse
+ */
+export const WriteFoldedResponse = new WriteFoldedResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteJFRRequest$Type extends MessageType<WriteJFRRequest> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteJFRRequest", [
+            { no: 1, name: "labels", kind: "message", T: () => LabelSet },
+            { no: 2, name: "jfr", kind: "scalar", T: 12 /*ScalarType.BYTES*/ }
+        ]);
+    }
+    create(value?: PartialMessage<WriteJFRRequest>): WriteJFRRequest {
+        const message = { jfr: new Uint8Array(0) };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteJFRRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteJFRRequest): WriteJFRRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labels */ 1:
+                    message.labels = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labels);
+                    break;
+                case /* bytes jfr */ 2:
+                    message.jfr = reader.bytes();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: WriteJFRRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labels = 1; */
+        if (message.labels)
+            LabelSet.internalBinaryWrite(message.labels, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* bytes jfr = 2; */
+        if (message.jfr.length)
+            writer.tag(2, WireType.LengthDelimited).bytes(message.jfr);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteJFRRequest
+ */
+export const WriteJFRRequest = new WriteJFRRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class WriteJFRResponse$Type extends MessageType<WriteJFRResponse> {
+    constructor() {
+        super("parca.profilestore.v1alpha1.WriteJFRResponse", []);
+    }
+    create(value?: PartialMessage<WriteJFRResponse>): WriteJFRResponse {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<WriteJFRResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: WriteJFRResponse): WriteJFRResponse {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: WriteJFRResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.profilestore.v1alpha1.WriteJFRResponse
+ */
+export const WriteJFRResponse = new WriteJFRResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class RawProfileSeries$Type extends MessageType<RawProfileSeries> {
     constructor() {
         super("parca.profilestore.v1alpha1.RawProfileSeries", [
@@ -402,5 +926,9 @@ export const RawSample = new RawSample$Type();
  * @generated ServiceType for protobuf service parca.profilestore.v1alpha1.ProfileStoreService
  */
 export const ProfileStoreService = new ServiceType("parca.profilestore.v1alpha1.ProfileStoreService", [
-    { name: "WriteRaw", options: { "google.api.http": { post: "/profiles/writeraw", body: "*" } }, I: WriteRawRequest, O: WriteRawResponse }
+    { name: "WriteRaw", options: { "google.api.http": { post: "/profiles/writeraw", body: "*" } }, I: WriteRawRequest, O: WriteRawResponse },
+    { name: "WriteStream", clientStreaming: true, options: {}, I: WriteStreamRequest, O: WriteStreamResponse },
+    { name: "WriteArrow", options: { "google.api.http": { post: "/profiles/writearrow", body: "*" } }, I: WriteArrowRequest, O: WriteArrowResponse },
+    { name: "WriteFolded", options: { "google.api.http": { post: "/profiles/writefolded", body: "*" } }, I: WriteFoldedRequest, O: WriteFoldedResponse },
+    { name: "WriteJFR", options: { "google.api.http": { post: "/profiles/writejfr", body: "*" } }, I: WriteJFRRequest, O: WriteJFRResponse }
 ]);
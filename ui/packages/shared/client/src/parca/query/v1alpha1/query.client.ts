@@ -40,7 +40,7 @@ export interface IQueryServiceClient {
      */
     query(input: QueryRequest, options?: RpcOptions): UnaryCall<QueryRequest, QueryResponse>;
     /**
-     * Series is unimplemented
+     * Series returns the set of series matching the selectors in the time frame
      *
      * @generated from protobuf rpc: Series(parca.query.v1alpha1.SeriesRequest) returns (parca.query.v1alpha1.SeriesResponse);
      */
@@ -100,7 +100,7 @@ export class QueryServiceClient implements IQueryServiceClient, ServiceInfo {
         return stackIntercept<QueryRequest, QueryResponse>("unary", this._transport, method, opt, input);
     }
     /**
-     * Series is unimplemented
+     * Series returns the set of series matching the selectors in the time frame
      *
      * @generated from protobuf rpc: Series(parca.query.v1alpha1.SeriesRequest) returns (parca.query.v1alpha1.SeriesResponse);
      */
@@ -12,11 +12,13 @@ import type { PartialMessage } from "@protobuf-ts/runtime";
 import { reflectionMergePartial } from "@protobuf-ts/runtime";
 import { MESSAGE_TYPE } from "@protobuf-ts/runtime";
 import { MessageType } from "@protobuf-ts/runtime";
+import { Label } from "../../profilestore/v1alpha1/profilestore";
 import { Line } from "../../metastore/v1alpha1/metastore";
 import { Function } from "../../metastore/v1alpha1/metastore";
 import { Mapping } from "../../metastore/v1alpha1/metastore";
 import { Location } from "../../metastore/v1alpha1/metastore";
 import { LabelSet } from "../../profilestore/v1alpha1/profilestore";
+import { Duration } from "../../../google/protobuf/duration";
 import { Timestamp } from "../../../google/protobuf/timestamp";
 /**
  * ProfileTypesRequest is the request to retrieve the list of available profile types.
@@ -90,6 +92,10 @@ export interface QueryRangeRequest {
     /**
      * query is the query string to match profiles against
      *
+     * Matchers of labels named pprof_<name> match the samples by their pprof
+     * label <name>, and those named pprof_num_<name> by their numeric pprof label
+     * <name>, e.g. {pprof_endpoint="/checkout"}.
+     *
      * @generated from protobuf field: string query = 1;
      */
     query: string;
@@ -106,11 +112,59 @@ export interface QueryRangeRequest {
      */
     end?: Timestamp;
     /**
-     * limit is the max number of profiles to include in the response
+     * limit is the max number of series to include in the response, the series
+     * with the largest total values are kept
      *
      * @generated from protobuf field: uint32 limit = 4;
      */
     limit: number;
+    /**
+     * step is the resolution of the samples of the series, the values of the
+     * profiles within a step are aggregated
+     *
+     * @generated from protobuf field: google.protobuf.Duration step = 5;
+     */
+    step?: Duration;
+    /**
+     * group_by are the label names the series are grouped by, all other labels
+     * are dropped and the values of the profiles of a group are aggregated. If
+     * empty, each full label set is a series of its own.
+     *
+     * @generated from protobuf field: repeated string group_by = 6;
+     */
+    groupBy: string[];
+    /**
+     * aggregation is the function the values of the profiles within a step and
+     * group are aggregated with
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryRangeRequest.Aggregation aggregation = 7;
+     */
+    aggregation: QueryRangeRequest_Aggregation;
+}
+/**
+ * Aggregation is the function the values of profiles are aggregated with
+ *
+ * @generated from protobuf enum parca.query.v1alpha1.QueryRangeRequest.Aggregation
+ */
+export enum QueryRangeRequest_Aggregation {
+    /**
+     * AGGREGATION_SUM_UNSPECIFIED sums the values
+     *
+     * @generated from protobuf enum value: AGGREGATION_SUM_UNSPECIFIED = 0;
+     */
+    SUM_UNSPECIFIED = 0,
+    /**
+     * AGGREGATION_MAX takes the maximum of the values
+     *
+     * @generated from protobuf enum value: AGGREGATION_MAX = 1;
+     */
+    MAX = 1,
+    /**
+     * AGGREGATION_AVG takes the average of the values
+     *
+     * @generated from protobuf enum value: AGGREGATION_AVG = 2;
+     */
+    AVG = 2
 }
 /**
  * QueryRangeResponse is the set of matching profile values
@@ -184,6 +238,10 @@ export interface MergeProfile {
     /**
      * query is the query string to match profiles for merge
      *
+     * Matchers of labels named pprof_<name> match the samples by their pprof
+     * label <name>, and those named pprof_num_<name> by their numeric pprof label
+     * <name>, e.g. {pprof_endpoint="/checkout"}.
+     *
      * @generated from protobuf field: string query = 1;
      */
     query: string;
@@ -215,6 +273,10 @@ export interface SingleProfile {
     /**
      * query is the query string to retrieve the profile
      *
+     * Matchers of labels named pprof_<name> match the samples by their pprof
+     * label <name>, and those named pprof_num_<name> by their numeric pprof label
+     * <name>, e.g. {pprof_endpoint="/checkout"}.
+     *
      * @generated from protobuf field: string query = 2;
      */
     query: string;
@@ -340,6 +402,73 @@ export interface QueryRequest {
      * @generated from protobuf field: parca.query.v1alpha1.QueryRequest.ReportType report_type = 5;
      */
     reportType: QueryRequest_ReportType;
+    /**
+     * group_by are the pprof label names the flame graph is grouped by, the
+     * first levels of the flame graph are the values of the labels in the given
+     * order with the stacks of the samples beneath them. It is not supported
+     * for diff queries.
+     *
+     * @generated from protobuf field: repeated string group_by = 6;
+     */
+    groupBy: string[];
+    /**
+     * source_reference selects the source lines reported by the source report
+     * type, the functions disassembled by the disassembly report type and the
+     * function of the sandwich report type, it is required for these report types
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.SourceReference source_reference = 7;
+     */
+    sourceReference?: SourceReference;
+    /**
+     * node_trim_threshold is the fraction of the total value below which the
+     * cumulative value of flame graph nodes must not be, smaller nodes are
+     * trimmed. It must be between 0 and 1, 0 keeps all nodes.
+     *
+     * @generated from protobuf field: float node_trim_threshold = 8;
+     */
+    nodeTrimThreshold: number;
+    /**
+     * max_depth is the maximum depth of flame graph nodes, deeper nodes are
+     * trimmed. 0 means unlimited.
+     *
+     * @generated from protobuf field: uint32 max_depth = 9;
+     */
+    maxDepth: number;
+    /**
+     * collapse_trimmed collapses the nodes trimmed by the node_trim_threshold
+     * into one other node per parent, which keeps their values
+     *
+     * @generated from protobuf field: bool collapse_trimmed = 10;
+     */
+    collapseTrimmed: boolean;
+    /**
+     * focus is a regular expression, only samples with a function on their stack
+     * whose name matches it are reported
+     *
+     * @generated from protobuf field: string focus = 11;
+     */
+    focus: string;
+    /**
+     * ignore is a regular expression, samples with a function on their stack
+     * whose name matches it are not reported
+     *
+     * @generated from protobuf field: string ignore = 12;
+     */
+    ignore: string;
+    /**
+     * hide is a regular expression, functions whose name matches it are removed
+     * from the stacks of the samples
+     *
+     * @generated from protobuf field: string hide = 13;
+     */
+    hide: string;
+    /**
+     * show is a regular expression, only functions whose name matches it are
+     * kept on the stacks of the samples
+     *
+     * @generated from protobuf field: string show = 14;
+     */
+    show: string;
 }
 /**
  * Mode is the type of query request
@@ -389,7 +518,54 @@ export enum QueryRequest_ReportType {
      *
      * @generated from protobuf enum value: REPORT_TYPE_TOP = 2;
      */
-    TOP = 2
+    TOP = 2,
+    /**
+     * REPORT_TYPE_CALLGRAPH unspecified
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_CALLGRAPH = 3;
+     */
+    CALLGRAPH = 3,
+    /**
+     * REPORT_TYPE_SOURCE unspecified
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_SOURCE = 4;
+     */
+    SOURCE = 4,
+    /**
+     * REPORT_TYPE_DISASSEMBLY unspecified
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_DISASSEMBLY = 5;
+     */
+    DISASSEMBLY = 5,
+    /**
+     * REPORT_TYPE_SANDWICH unspecified
+     *
+     * @generated from protobuf enum value: REPORT_TYPE_SANDWICH = 6;
+     */
+    SANDWICH = 6
+}
+/**
+ * SourceReference selects the source lines of a source report, lines matching
+ * all of the set fields are reported. A disassembly report disassembles the
+ * functions containing the addresses of the matching lines. A sandwich report
+ * requires the function to be set.
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SourceReference
+ */
+export interface SourceReference {
+    /**
+     * function is the name of the function whose lines are reported
+     *
+     * @generated from protobuf field: string function = 1;
+     */
+    function: string;
+    /**
+     * filename is the source file whose lines are reported, it matches files with
+     * the same name or a path ending in it
+     *
+     * @generated from protobuf field: string filename = 2;
+     */
+    filename: string;
 }
 /**
  * Top is the top report type
@@ -485,98 +661,85 @@ export interface TopNodeMeta {
     line?: Line;
 }
 /**
- * Flamegraph is the flame graph report type
+ * Callgraph is the callgraph report type
  *
- * @generated from protobuf message parca.query.v1alpha1.Flamegraph
+ * @generated from protobuf message parca.query.v1alpha1.Callgraph
  */
-export interface Flamegraph {
+export interface Callgraph {
     /**
-     * root is the root of the flame graph
+     * nodes are the functions of the callgraph
      *
-     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphRootNode root = 1;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphNode nodes = 1;
      */
-    root?: FlamegraphRootNode;
+    nodes: CallgraphNode[];
     /**
-     * total is the total weight of the flame graph
+     * edges are the calls between the functions of the callgraph
      *
-     * @generated from protobuf field: int64 total = 2;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.CallgraphEdge edges = 2;
      */
-    total: string;
+    edges: CallgraphEdge[];
     /**
-     * unit is the unit represented by the flame graph
+     * cumulative is the total weight of the callgraph
      *
-     * @generated from protobuf field: string unit = 3;
+     * @generated from protobuf field: int64 cumulative = 3;
      */
-    unit: string;
+    cumulative: string;
     /**
-     * height is the max height of the graph
+     * unit is the unit represented by the callgraph
      *
-     * @generated from protobuf field: int32 height = 4;
+     * @generated from protobuf field: string unit = 4;
      */
-    height: number;
+    unit: string;
 }
 /**
- * FlamegraphRootNode is a root node of a flame graph
+ * CallgraphNode is a function in a callgraph
  *
- * @generated from protobuf message parca.query.v1alpha1.FlamegraphRootNode
+ * @generated from protobuf message parca.query.v1alpha1.CallgraphNode
  */
-export interface FlamegraphRootNode {
-    /**
-     * cumulative is the cumulative value of the graph
-     *
-     * @generated from protobuf field: int64 cumulative = 1;
-     */
-    cumulative: string;
+export interface CallgraphNode {
     /**
-     * diff is the diff
+     * id is the unique identifier of the node within the callgraph
      *
-     * @generated from protobuf field: int64 diff = 2;
+     * @generated from protobuf field: string id = 1;
      */
-    diff: string;
+    id: string;
     /**
-     * children are the list of the children of the root node
+     * meta is the metadata about the node
      *
-     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 3;
+     * @generated from protobuf field: parca.query.v1alpha1.CallgraphNodeMeta meta = 2;
      */
-    children: FlamegraphNode[];
-}
-/**
- * FlamegraphNode represents a node in the graph
- *
- * @generated from protobuf message parca.query.v1alpha1.FlamegraphNode
- */
-export interface FlamegraphNode {
+    meta?: CallgraphNodeMeta;
     /**
-     * meta is the metadata about the node
+     * cumulative is the value of the samples the function is on the stack of
      *
-     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphNodeMeta meta = 1;
+     * @generated from protobuf field: int64 cumulative = 3;
      */
-    meta?: FlamegraphNodeMeta;
+    cumulative: string;
     /**
-     * cumulative is the cumulative value of the node
+     * flat is the value of the samples the function is the leaf of
      *
-     * @generated from protobuf field: int64 cumulative = 2;
+     * @generated from protobuf field: int64 flat = 4;
      */
-    cumulative: string;
+    flat: string;
     /**
-     * diff is the diff
+     * diff is the diff of the cumulative value between two profiles
      *
-     * @generated from protobuf field: int64 diff = 3;
+     * @generated from protobuf field: int64 diff = 5;
      */
     diff: string;
     /**
-     * children are the child nodes
+     * flat_diff is the diff of the flat value between two profiles
      *
-     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 4;
+     * @generated from protobuf field: int64 flat_diff = 6;
      */
-    children: FlamegraphNode[];
+    flatDiff: string;
 }
 /**
- * FlamegraphNodeMeta is the metadata for a given node
+ * CallgraphNodeMeta is the metadata for a given node
  *
- * @generated from protobuf message parca.query.v1alpha1.FlamegraphNodeMeta
+ * @generated from protobuf message parca.query.v1alpha1.CallgraphNodeMeta
  */
-export interface FlamegraphNodeMeta {
+export interface CallgraphNodeMeta {
     /**
      * location is the location for the code
      *
@@ -603,235 +766,1497 @@ export interface FlamegraphNodeMeta {
     line?: Line;
 }
 /**
- * QueryResponse is the returned report for the given query
+ * CallgraphEdge is a call from one function to another in a callgraph
  *
- * @generated from protobuf message parca.query.v1alpha1.QueryResponse
+ * @generated from protobuf message parca.query.v1alpha1.CallgraphEdge
  */
-export interface QueryResponse {
+export interface CallgraphEdge {
     /**
-     * @generated from protobuf oneof: report
+     * id is the unique identifier of the edge within the callgraph
+     *
+     * @generated from protobuf field: string id = 1;
      */
-    report: {
-        oneofKind: "flamegraph";
-        /**
-         * flamegraph is a flamegraph representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 5;
-         */
-        flamegraph: Flamegraph;
-    } | {
-        oneofKind: "pprof";
-        /**
-         * pprof is a pprof profile as compressed bytes
-         *
-         * @generated from protobuf field: bytes pprof = 6;
-         */
-        pprof: Uint8Array;
-    } | {
-        oneofKind: "top";
-        /**
-         * top is a top list representation of the report
-         *
-         * @generated from protobuf field: parca.query.v1alpha1.Top top = 7;
-         */
-        top: Top;
-    } | {
-        oneofKind: undefined;
-    };
-}
-/**
- * SeriesRequest is unimplemented
- *
- * @generated from protobuf message parca.query.v1alpha1.SeriesRequest
- */
-export interface SeriesRequest {
+    id: string;
     /**
-     * match ...
+     * source is the id of the calling node
      *
-     * @generated from protobuf field: repeated string match = 1;
+     * @generated from protobuf field: string source = 2;
      */
-    match: string[];
+    source: string;
     /**
-     * start ...
+     * target is the id of the called node
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     * @generated from protobuf field: string target = 3;
      */
-    start?: Timestamp;
+    target: string;
     /**
-     * end ...
+     * cumulative is the value of the samples the call is on the stack of
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     * @generated from protobuf field: int64 cumulative = 4;
      */
-    end?: Timestamp;
-}
-/**
- * SeriesResponse is unimplemented
- *
- * @generated from protobuf message parca.query.v1alpha1.SeriesResponse
- */
-export interface SeriesResponse {
+    cumulative: string;
+    /**
+     * diff is the diff of the cumulative value between two profiles
+     *
+     * @generated from protobuf field: int64 diff = 5;
+     */
+    diff: string;
 }
 /**
- * LabelsRequest are the request values for labels
+ * Source is the source report type
  *
- * @generated from protobuf message parca.query.v1alpha1.LabelsRequest
+ * @generated from protobuf message parca.query.v1alpha1.Source
  */
-export interface LabelsRequest {
+export interface Source {
     /**
-     * match are the set of matching strings
+     * files are the source files with reported lines, ordered by filename
      *
-     * @generated from protobuf field: repeated string match = 1;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.SourceFile files = 1;
      */
-    match: string[];
+    files: SourceFile[];
     /**
-     * start is the start of the time window to perform the query
+     * cumulative is the total weight of the profile
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     * @generated from protobuf field: int64 cumulative = 2;
      */
-    start?: Timestamp;
+    cumulative: string;
     /**
-     * end is the end of the time window to perform the query
+     * unit is the unit represented by the source report
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     * @generated from protobuf field: string unit = 3;
      */
-    end?: Timestamp;
+    unit: string;
 }
 /**
- * LabelsResponse is the set of matching label names
+ * SourceFile is a source file in a source report
  *
- * @generated from protobuf message parca.query.v1alpha1.LabelsResponse
+ * @generated from protobuf message parca.query.v1alpha1.SourceFile
  */
-export interface LabelsResponse {
+export interface SourceFile {
     /**
-     * / label_names are the set of matching label names
+     * filename is the name of the source file
      *
-     * @generated from protobuf field: repeated string label_names = 1;
+     * @generated from protobuf field: string filename = 1;
      */
-    labelNames: string[];
+    filename: string;
     /**
-     * warnings is unimplemented
+     * lines are the reported lines of the file, ordered by line number
      *
-     * @generated from protobuf field: repeated string warnings = 2;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.SourceLine lines = 2;
      */
-    warnings: string[];
+    lines: SourceLine[];
 }
 /**
- * ValuesRequest are the request values for a values request
+ * SourceLine is a line in a source file
  *
- * @generated from protobuf message parca.query.v1alpha1.ValuesRequest
+ * @generated from protobuf message parca.query.v1alpha1.SourceLine
  */
-export interface ValuesRequest {
+export interface SourceLine {
     /**
-     * label_name is the label name to match values against
+     * line is the line number
      *
-     * @generated from protobuf field: string label_name = 1;
+     * @generated from protobuf field: int64 line = 1;
      */
-    labelName: string;
+    line: string;
     /**
-     * match are the set of matching strings to match values against
+     * flat is the value of the samples the line is the leaf of
      *
-     * @generated from protobuf field: repeated string match = 2;
+     * @generated from protobuf field: int64 flat = 2;
      */
-    match: string[];
+    flat: string;
     /**
-     * start is the start of the time window to perform the query
+     * cumulative is the value of the samples the line is on the stack of
      *
-     * @generated from protobuf field: google.protobuf.Timestamp start = 3;
+     * @generated from protobuf field: int64 cumulative = 3;
      */
-    start?: Timestamp;
+    cumulative: string;
     /**
-     * end is the end of the time window to perform the query
+     * diff is the diff of the cumulative value between two profiles
      *
-     * @generated from protobuf field: google.protobuf.Timestamp end = 4;
+     * @generated from protobuf field: int64 diff = 4;
      */
-    end?: Timestamp;
+    diff: string;
+    /**
+     * flat_diff is the diff of the flat value between two profiles
+     *
+     * @generated from protobuf field: int64 flat_diff = 5;
+     */
+    flatDiff: string;
 }
 /**
- * ValuesResponse are the set of matching values
+ * Disassembly is the disassembly report type
  *
- * @generated from protobuf message parca.query.v1alpha1.ValuesResponse
+ * @generated from protobuf message parca.query.v1alpha1.Disassembly
  */
-export interface ValuesResponse {
+export interface Disassembly {
     /**
-     * label_values are the set of matching label values
+     * functions are the disassembled functions, ordered by mapping and address
      *
-     * @generated from protobuf field: repeated string label_values = 1;
+     * @generated from protobuf field: repeated parca.query.v1alpha1.DisassembledFunction functions = 1;
      */
-    labelValues: string[];
+    functions: DisassembledFunction[];
     /**
-     * warnings is unimplemented
+     * cumulative is the total weight of the profile
      *
-     * @generated from protobuf field: repeated string warnings = 2;
+     * @generated from protobuf field: int64 cumulative = 2;
      */
-    warnings: string[];
+    cumulative: string;
+    /**
+     * unit is the unit represented by the disassembly report
+     *
+     * @generated from protobuf field: string unit = 3;
+     */
+    unit: string;
 }
 /**
- * ValueType represents a value, including its type and unit
+ * DisassembledFunction is a disassembled function of an object file
  *
- * @generated from protobuf message parca.query.v1alpha1.ValueType
+ * @generated from protobuf message parca.query.v1alpha1.DisassembledFunction
  */
-export interface ValueType {
+export interface DisassembledFunction {
     /**
-     * type is the type of the value
+     * name is the symbol name of the function
      *
-     * @generated from protobuf field: string type = 1;
+     * @generated from protobuf field: string name = 1;
      */
-    type: string;
+    name: string;
     /**
-     * unit is the unit of the value
+     * mapping is the mapping of the object file containing the function
      *
-     * @generated from protobuf field: string unit = 2;
+     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
+     */
+    mapping?: Mapping;
+    /**
+     * start is the address of the first instruction of the function
+     *
+     * @generated from protobuf field: uint64 start = 3;
+     */
+    start: string;
+    /**
+     * end is the address following the last instruction of the function
+     *
+     * @generated from protobuf field: uint64 end = 4;
+     */
+    end: string;
+    /**
+     * instructions are the instructions of the function, ordered by address
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.DisassembledInstruction instructions = 5;
+     */
+    instructions: DisassembledInstruction[];
+}
+/**
+ * DisassembledInstruction is a machine instruction of a disassembled function
+ *
+ * @generated from protobuf message parca.query.v1alpha1.DisassembledInstruction
+ */
+export interface DisassembledInstruction {
+    /**
+     * address is the address of the instruction
+     *
+     * @generated from protobuf field: uint64 address = 1;
+     */
+    address: string;
+    /**
+     * text is the instruction in GNU assembler syntax
+     *
+     * @generated from protobuf field: string text = 2;
+     */
+    text: string;
+    /**
+     * flat is the value of the samples the instruction is the leaf of
+     *
+     * @generated from protobuf field: int64 flat = 3;
+     */
+    flat: string;
+    /**
+     * cumulative is the value of the samples the instruction is on the stack of
+     *
+     * @generated from protobuf field: int64 cumulative = 4;
+     */
+    cumulative: string;
+    /**
+     * diff is the diff of the cumulative value between two profiles
+     *
+     * @generated from protobuf field: int64 diff = 5;
+     */
+    diff: string;
+    /**
+     * flat_diff is the diff of the flat value between two profiles
+     *
+     * @generated from protobuf field: int64 flat_diff = 6;
+     */
+    flatDiff: string;
+}
+/**
+ * Sandwich is the sandwich report type, the callers and callees of a function
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Sandwich
+ */
+export interface Sandwich {
+    /**
+     * callers is the inverted flame graph of the stacks leading to the function,
+     * rooted at the function with its callers beneath it
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.Flamegraph callers = 1;
+     */
+    callers?: Flamegraph;
+    /**
+     * callees is the flame graph of the stacks beneath the function, rooted at
+     * the function
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.Flamegraph callees = 2;
+     */
+    callees?: Flamegraph;
+}
+/**
+ * Flamegraph is the flame graph report type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Flamegraph
+ */
+export interface Flamegraph {
+    /**
+     * root is the root of the flame graph
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphRootNode root = 1;
+     */
+    root?: FlamegraphRootNode;
+    /**
+     * total is the total weight of the flame graph
+     *
+     * @generated from protobuf field: int64 total = 2;
+     */
+    total: string;
+    /**
+     * unit is the unit represented by the flame graph
+     *
+     * @generated from protobuf field: string unit = 3;
      */
     unit: string;
+    /**
+     * height is the max height of the graph
+     *
+     * @generated from protobuf field: int32 height = 4;
+     */
+    height: number;
+    /**
+     * trimmed is the number of nodes trimmed from the graph
+     *
+     * @generated from protobuf field: int64 trimmed = 5;
+     */
+    trimmed: string;
 }
 /**
- * ShareProfileRequest represents the query denoting the profile and a description about the profile
+ * FlamegraphRootNode is a root node of a flame graph
  *
- * @generated from protobuf message parca.query.v1alpha1.ShareProfileRequest
+ * @generated from protobuf message parca.query.v1alpha1.FlamegraphRootNode
  */
-export interface ShareProfileRequest {
+export interface FlamegraphRootNode {
     /**
-     * QueryRequest that refers to the profile to be shared
+     * cumulative is the cumulative value of the graph
      *
-     * @generated from protobuf field: parca.query.v1alpha1.QueryRequest query_request = 1;
+     * @generated from protobuf field: int64 cumulative = 1;
      */
-    queryRequest?: QueryRequest;
+    cumulative: string;
     /**
-     * description about the profile
+     * diff is the diff
      *
-     * @generated from protobuf field: optional string description = 2;
+     * @generated from protobuf field: int64 diff = 2;
      */
-    description?: string;
+    diff: string;
+    /**
+     * children are the list of the children of the root node
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 3;
+     */
+    children: FlamegraphNode[];
+}
+/**
+ * FlamegraphNode represents a node in the graph
+ *
+ * @generated from protobuf message parca.query.v1alpha1.FlamegraphNode
+ */
+export interface FlamegraphNode {
+    /**
+     * meta is the metadata about the node
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.FlamegraphNodeMeta meta = 1;
+     */
+    meta?: FlamegraphNodeMeta;
+    /**
+     * cumulative is the cumulative value of the node
+     *
+     * @generated from protobuf field: int64 cumulative = 2;
+     */
+    cumulative: string;
+    /**
+     * diff is the diff
+     *
+     * @generated from protobuf field: int64 diff = 3;
+     */
+    diff: string;
+    /**
+     * children are the child nodes
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.FlamegraphNode children = 4;
+     */
+    children: FlamegraphNode[];
+}
+/**
+ * FlamegraphNodeMeta is the metadata for a given node
+ *
+ * @generated from protobuf message parca.query.v1alpha1.FlamegraphNodeMeta
+ */
+export interface FlamegraphNodeMeta {
+    /**
+     * location is the location for the code
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Location location = 1;
+     */
+    location?: Location;
+    /**
+     * mapping is the mapping into code
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Mapping mapping = 2;
+     */
+    mapping?: Mapping;
+    /**
+     * function is the function information
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Function function = 3;
+     */
+    function?: Function;
+    /**
+     * line is the line location
+     *
+     * @generated from protobuf field: parca.metastore.v1alpha1.Line line = 4;
+     */
+    line?: Line;
+    /**
+     * label is the pprof label the samples beneath the node are grouped by, it
+     * is only set for nodes of the levels of a flame graph grouped by labels
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.Label label = 5;
+     */
+    label?: Label;
+    /**
+     * other is set for the node collapsing the trimmed children of its parent
+     *
+     * @generated from protobuf field: bool other = 6;
+     */
+    other: boolean;
+}
+/**
+ * QueryResponse is the returned report for the given query
+ *
+ * @generated from protobuf message parca.query.v1alpha1.QueryResponse
+ */
+export interface QueryResponse {
+    /**
+     * @generated from protobuf oneof: report
+     */
+    report: {
+        oneofKind: "flamegraph";
+        /**
+         * flamegraph is a flamegraph representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Flamegraph flamegraph = 5;
+         */
+        flamegraph: Flamegraph;
+    } | {
+        oneofKind: "pprof";
+        /**
+         * pprof is a pprof profile as compressed bytes
+         *
+         * @generated from protobuf field: bytes pprof = 6;
+         */
+        pprof: Uint8Array;
+    } | {
+        oneofKind: "top";
+        /**
+         * top is a top list representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Top top = 7;
+         */
+        top: Top;
+    } | {
+        oneofKind: "callgraph";
+        /**
+         * callgraph is a callgraph nodes and edges representation of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Callgraph callgraph = 8;
+         */
+        callgraph: Callgraph;
+    } | {
+        oneofKind: "source";
+        /**
+         * source is a per line representation of source files of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Source source = 9;
+         */
+        source: Source;
+    } | {
+        oneofKind: "disassembly";
+        /**
+         * disassembly is a per instruction representation of functions of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Disassembly disassembly = 10;
+         */
+        disassembly: Disassembly;
+    } | {
+        oneofKind: "sandwich";
+        /**
+         * sandwich is the callers and callees of a function of the report
+         *
+         * @generated from protobuf field: parca.query.v1alpha1.Sandwich sandwich = 11;
+         */
+        sandwich: Sandwich;
+    } | {
+        oneofKind: undefined;
+    };
+}
+/**
+ * SeriesRequest are the request values for series
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SeriesRequest
+ */
+export interface SeriesRequest {
+    /**
+     * match are the set of selectors, a series matching any of them is returned
+     *
+     * @generated from protobuf field: repeated string match = 1;
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     */
+    end?: Timestamp;
+}
+/**
+ * SeriesResponse is the set of matching series
+ *
+ * @generated from protobuf message parca.query.v1alpha1.SeriesResponse
+ */
+export interface SeriesResponse {
+    /**
+     * series are the set of matching series
+     *
+     * @generated from protobuf field: repeated parca.query.v1alpha1.Series series = 1;
+     */
+    series: Series[];
+}
+/**
+ * Series is a label set of a profile type
+ *
+ * @generated from protobuf message parca.query.v1alpha1.Series
+ */
+export interface Series {
+    /**
+     * labelset is the set of key value pairs
+     *
+     * @generated from protobuf field: parca.profilestore.v1alpha1.LabelSet labelset = 1;
+     */
+    labelset?: LabelSet;
+    /**
+     * profile_type is the profile type of the series
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.ProfileType profile_type = 2;
+     */
+    profileType?: ProfileType;
+}
+/**
+ * LabelsRequest are the request values for labels
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelsRequest
+ */
+export interface LabelsRequest {
+    /**
+     * match are the set of matching strings
+     *
+     * @generated from protobuf field: repeated string match = 1;
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 2;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 3;
+     */
+    end?: Timestamp;
+}
+/**
+ * LabelsResponse is the set of matching label names
+ *
+ * @generated from protobuf message parca.query.v1alpha1.LabelsResponse
+ */
+export interface LabelsResponse {
+    /**
+     * / label_names are the set of matching label names
+     *
+     * @generated from protobuf field: repeated string label_names = 1;
+     */
+    labelNames: string[];
+    /**
+     * warnings are set if the results were truncated
+     *
+     * @generated from protobuf field: repeated string warnings = 2;
+     */
+    warnings: string[];
+}
+/**
+ * ValuesRequest are the request values for a values request
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValuesRequest
+ */
+export interface ValuesRequest {
+    /**
+     * label_name is the label name to match values against
+     *
+     * @generated from protobuf field: string label_name = 1;
+     */
+    labelName: string;
+    /**
+     * match are the set of matching strings to match values against
+     *
+     * @generated from protobuf field: repeated string match = 2;
+     */
+    match: string[];
+    /**
+     * start is the start of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp start = 3;
+     */
+    start?: Timestamp;
+    /**
+     * end is the end of the time window to perform the query
+     *
+     * @generated from protobuf field: google.protobuf.Timestamp end = 4;
+     */
+    end?: Timestamp;
+}
+/**
+ * ValuesResponse are the set of matching values
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValuesResponse
+ */
+export interface ValuesResponse {
+    /**
+     * label_values are the set of matching label values
+     *
+     * @generated from protobuf field: repeated string label_values = 1;
+     */
+    labelValues: string[];
+    /**
+     * warnings are set if the results were truncated
+     *
+     * @generated from protobuf field: repeated string warnings = 2;
+     */
+    warnings: string[];
+}
+/**
+ * ValueType represents a value, including its type and unit
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ValueType
+ */
+export interface ValueType {
+    /**
+     * type is the type of the value
+     *
+     * @generated from protobuf field: string type = 1;
+     */
+    type: string;
+    /**
+     * unit is the unit of the value
+     *
+     * @generated from protobuf field: string unit = 2;
+     */
+    unit: string;
+}
+/**
+ * ShareProfileRequest represents the query denoting the profile and a description about the profile
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ShareProfileRequest
+ */
+export interface ShareProfileRequest {
+    /**
+     * QueryRequest that refers to the profile to be shared
+     *
+     * @generated from protobuf field: parca.query.v1alpha1.QueryRequest query_request = 1;
+     */
+    queryRequest?: QueryRequest;
+    /**
+     * description about the profile
+     *
+     * @generated from protobuf field: optional string description = 2;
+     */
+    description?: string;
+}
+/**
+ * ShareProfileResponse represents the shared link of a profile
+ *
+ * @generated from protobuf message parca.query.v1alpha1.ShareProfileResponse
+ */
+export interface ShareProfileResponse {
+    /**
+     * link to access the profile
+     *
+     * @generated from protobuf field: string link = 1;
+     */
+    link: string;
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileTypesRequest", []);
+    }
+    create(value?: PartialMessage<ProfileTypesRequest>): ProfileTypesRequest {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileTypesRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesRequest): ProfileTypesRequest {
+        return target ?? this.create();
+    }
+    internalBinaryWrite(message: ProfileTypesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesRequest
+ */
+export const ProfileTypesRequest = new ProfileTypesRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileTypesResponse", [
+            { no: 1, name: "types", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ProfileType }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileTypesResponse>): ProfileTypesResponse {
+        const message = { types: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileTypesResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesResponse): ProfileTypesResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.ProfileType types */ 1:
+                    message.types.push(ProfileType.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileTypesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.ProfileType types = 1; */
+        for (let i = 0; i < message.types.length; i++)
+            ProfileType.internalBinaryWrite(message.types[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesResponse
+ */
+export const ProfileTypesResponse = new ProfileTypesResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileType$Type extends MessageType<ProfileType> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileType", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "delta", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileType>): ProfileType {
+        const message = { name: "", sampleType: "", sampleUnit: "", periodType: "", periodUnit: "", delta: false };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileType>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileType): ProfileType {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string name */ 1:
+                    message.name = reader.string();
+                    break;
+                case /* string sample_type */ 2:
+                    message.sampleType = reader.string();
+                    break;
+                case /* string sample_unit */ 3:
+                    message.sampleUnit = reader.string();
+                    break;
+                case /* string period_type */ 4:
+                    message.periodType = reader.string();
+                    break;
+                case /* string period_unit */ 5:
+                    message.periodUnit = reader.string();
+                    break;
+                case /* bool delta */ 6:
+                    message.delta = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileType, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* string sample_type = 2; */
+        if (message.sampleType !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
+        /* string sample_unit = 3; */
+        if (message.sampleUnit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
+        /* string period_type = 4; */
+        if (message.periodType !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
+        /* string period_unit = 5; */
+        if (message.periodUnit !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
+        /* bool delta = 6; */
+        if (message.delta !== false)
+            writer.tag(6, WireType.Varint).bool(message.delta);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileType
+ */
+export const ProfileType = new ProfileType$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRangeRequest", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp },
+            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "step", kind: "message", T: () => Duration },
+            { no: 6, name: "group_by", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "aggregation", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRangeRequest.Aggregation", QueryRangeRequest_Aggregation, "AGGREGATION_"] }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
+        const message = { query: "", limit: 0, groupBy: [], aggregation: 0 };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryRangeRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeRequest): QueryRangeRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                case /* uint32 limit */ 4:
+                    message.limit = reader.uint32();
+                    break;
+                case /* google.protobuf.Duration step */ 5:
+                    message.step = Duration.internalBinaryRead(reader, reader.uint32(), options, message.step);
+                    break;
+                case /* repeated string group_by */ 6:
+                    message.groupBy.push(reader.string());
+                    break;
+                case /* parca.query.v1alpha1.QueryRangeRequest.Aggregation aggregation */ 7:
+                    message.aggregation = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRangeRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* uint32 limit = 4; */
+        if (message.limit !== 0)
+            writer.tag(4, WireType.Varint).uint32(message.limit);
+        /* google.protobuf.Duration step = 5; */
+        if (message.step)
+            Duration.internalBinaryWrite(message.step, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* repeated string group_by = 6; */
+        for (let i = 0; i < message.groupBy.length; i++)
+            writer.tag(6, WireType.LengthDelimited).string(message.groupBy[i]);
+        /* parca.query.v1alpha1.QueryRangeRequest.Aggregation aggregation = 7; */
+        if (message.aggregation !== 0)
+            writer.tag(7, WireType.Varint).int32(message.aggregation);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeRequest
+ */
+export const QueryRangeRequest = new QueryRangeRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRangeResponse", [
+            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSeries }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
+        const message = { series: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryRangeResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeResponse): QueryRangeResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
+                    message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRangeResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeResponse
+ */
+export const QueryRangeResponse = new QueryRangeResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MetricsSeries$Type extends MessageType<MetricsSeries> {
+    constructor() {
+        super("parca.query.v1alpha1.MetricsSeries", [
+            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
+            { no: 2, name: "samples", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSample },
+            { no: 3, name: "period_type", kind: "message", T: () => ValueType },
+            { no: 4, name: "sample_type", kind: "message", T: () => ValueType }
+        ]);
+    }
+    create(value?: PartialMessage<MetricsSeries>): MetricsSeries {
+        const message = { samples: [] };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<MetricsSeries>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSeries): MetricsSeries {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
+                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                    break;
+                case /* repeated parca.query.v1alpha1.MetricsSample samples */ 2:
+                    message.samples.push(MetricsSample.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* parca.query.v1alpha1.ValueType period_type */ 3:
+                    message.periodType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.periodType);
+                    break;
+                case /* parca.query.v1alpha1.ValueType sample_type */ 4:
+                    message.sampleType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.sampleType);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MetricsSeries, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
+        if (message.labelset)
+            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.MetricsSample samples = 2; */
+        for (let i = 0; i < message.samples.length; i++)
+            MetricsSample.internalBinaryWrite(message.samples[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ValueType period_type = 3; */
+        if (message.periodType)
+            ValueType.internalBinaryWrite(message.periodType, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ValueType sample_type = 4; */
+        if (message.sampleType)
+            ValueType.internalBinaryWrite(message.sampleType, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSeries
+ */
+export const MetricsSeries = new MetricsSeries$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MetricsSample$Type extends MessageType<MetricsSample> {
+    constructor() {
+        super("parca.query.v1alpha1.MetricsSample", [
+            { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
+            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        ]);
+    }
+    create(value?: PartialMessage<MetricsSample>): MetricsSample {
+        const message = { value: "0" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<MetricsSample>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSample): MetricsSample {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp timestamp */ 1:
+                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
+                    break;
+                case /* int64 value */ 2:
+                    message.value = reader.int64().toString();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MetricsSample, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp timestamp = 1; */
+        if (message.timestamp)
+            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 value = 2; */
+        if (message.value !== "0")
+            writer.tag(2, WireType.Varint).int64(message.value);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSample
+ */
+export const MetricsSample = new MetricsSample$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MergeProfile$Type extends MessageType<MergeProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.MergeProfile", [
+            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "start", kind: "message", T: () => Timestamp },
+            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+        ]);
+    }
+    create(value?: PartialMessage<MergeProfile>): MergeProfile {
+        const message = { query: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<MergeProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MergeProfile): MergeProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string query */ 1:
+                    message.query = reader.string();
+                    break;
+                case /* google.protobuf.Timestamp start */ 2:
+                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                    break;
+                case /* google.protobuf.Timestamp end */ 3:
+                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MergeProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string query = 1; */
+        if (message.query !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.query);
+        /* google.protobuf.Timestamp start = 2; */
+        if (message.start)
+            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* google.protobuf.Timestamp end = 3; */
+        if (message.end)
+            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.MergeProfile
+ */
+export const MergeProfile = new MergeProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SingleProfile$Type extends MessageType<SingleProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.SingleProfile", [
+            { no: 1, name: "time", kind: "message", T: () => Timestamp },
+            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SingleProfile>): SingleProfile {
+        const message = { query: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<SingleProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SingleProfile): SingleProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* google.protobuf.Timestamp time */ 1:
+                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                    break;
+                case /* string query */ 2:
+                    message.query = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SingleProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* google.protobuf.Timestamp time = 1; */
+        if (message.time)
+            Timestamp.internalBinaryWrite(message.time, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string query = 2; */
+        if (message.query !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.query);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SingleProfile
+ */
+export const SingleProfile = new SingleProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class DiffProfile$Type extends MessageType<DiffProfile> {
+    constructor() {
+        super("parca.query.v1alpha1.DiffProfile", [
+            { no: 1, name: "a", kind: "message", T: () => ProfileDiffSelection },
+            { no: 2, name: "b", kind: "message", T: () => ProfileDiffSelection }
+        ]);
+    }
+    create(value?: PartialMessage<DiffProfile>): DiffProfile {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<DiffProfile>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DiffProfile): DiffProfile {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.ProfileDiffSelection a */ 1:
+                    message.a = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.a);
+                    break;
+                case /* parca.query.v1alpha1.ProfileDiffSelection b */ 2:
+                    message.b = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.b);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: DiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.ProfileDiffSelection a = 1; */
+        if (message.a)
+            ProfileDiffSelection.internalBinaryWrite(message.a, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ProfileDiffSelection b = 2; */
+        if (message.b)
+            ProfileDiffSelection.internalBinaryWrite(message.b, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DiffProfile
+ */
+export const DiffProfile = new DiffProfile$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
+    constructor() {
+        super("parca.query.v1alpha1.ProfileDiffSelection", [
+            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.ProfileDiffSelection.Mode", ProfileDiffSelection_Mode, "MODE_"] },
+            { no: 2, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
+            { no: 3, name: "single", kind: "message", oneof: "options", T: () => SingleProfile }
+        ]);
+    }
+    create(value?: PartialMessage<ProfileDiffSelection>): ProfileDiffSelection {
+        const message = { mode: 0, options: { oneofKind: undefined } };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<ProfileDiffSelection>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileDiffSelection): ProfileDiffSelection {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode */ 1:
+                    message.mode = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.MergeProfile merge */ 2:
+                    message.options = {
+                        oneofKind: "merge",
+                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.SingleProfile single */ 3:
+                    message.options = {
+                        oneofKind: "single",
+                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
+                    };
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ProfileDiffSelection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode = 1; */
+        if (message.mode !== 0)
+            writer.tag(1, WireType.Varint).int32(message.mode);
+        /* parca.query.v1alpha1.MergeProfile merge = 2; */
+        if (message.options.oneofKind === "merge")
+            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SingleProfile single = 3; */
+        if (message.options.oneofKind === "single")
+            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileDiffSelection
+ */
+export const ProfileDiffSelection = new ProfileDiffSelection$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class QueryRequest$Type extends MessageType<QueryRequest> {
+    constructor() {
+        super("parca.query.v1alpha1.QueryRequest", [
+            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.Mode", QueryRequest_Mode, "MODE_"] },
+            { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
+            { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
+            { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
+            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] },
+            { no: 6, name: "group_by", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "source_reference", kind: "message", T: () => SourceReference },
+            { no: 8, name: "node_trim_threshold", kind: "scalar", T: 2 /*ScalarType.FLOAT*/ },
+            { no: 9, name: "max_depth", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 10, name: "collapse_trimmed", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 11, name: "focus", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 12, name: "ignore", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 13, name: "hide", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 14, name: "show", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<QueryRequest>): QueryRequest {
+        const message = { mode: 0, options: { oneofKind: undefined }, reportType: 0, groupBy: [], nodeTrimThreshold: 0, maxDepth: 0, collapseTrimmed: false, focus: "", ignore: "", hide: "", show: "" };
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<QueryRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRequest): QueryRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.query.v1alpha1.QueryRequest.Mode mode */ 1:
+                    message.mode = reader.int32();
+                    break;
+                case /* parca.query.v1alpha1.DiffProfile diff */ 2:
+                    message.options = {
+                        oneofKind: "diff",
+                        diff: DiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).diff)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.MergeProfile merge */ 3:
+                    message.options = {
+                        oneofKind: "merge",
+                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.SingleProfile single */ 4:
+                    message.options = {
+                        oneofKind: "single",
+                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
+                    message.reportType = reader.int32();
+                    break;
+                case /* repeated string group_by */ 6:
+                    message.groupBy.push(reader.string());
+                    break;
+                case /* parca.query.v1alpha1.SourceReference source_reference */ 7:
+                    message.sourceReference = SourceReference.internalBinaryRead(reader, reader.uint32(), options, message.sourceReference);
+                    break;
+                case /* float node_trim_threshold */ 8:
+                    message.nodeTrimThreshold = reader.float();
+                    break;
+                case /* uint32 max_depth */ 9:
+                    message.maxDepth = reader.uint32();
+                    break;
+                case /* bool collapse_trimmed */ 10:
+                    message.collapseTrimmed = reader.bool();
+                    break;
+                case /* string focus */ 11:
+                    message.focus = reader.string();
+                    break;
+                case /* string ignore */ 12:
+                    message.ignore = reader.string();
+                    break;
+                case /* string hide */ 13:
+                    message.hide = reader.string();
+                    break;
+                case /* string show */ 14:
+                    message.show = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: QueryRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.QueryRequest.Mode mode = 1; */
+        if (message.mode !== 0)
+            writer.tag(1, WireType.Varint).int32(message.mode);
+        /* parca.query.v1alpha1.DiffProfile diff = 2; */
+        if (message.options.oneofKind === "diff")
+            DiffProfile.internalBinaryWrite(message.options.diff, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.MergeProfile merge = 3; */
+        if (message.options.oneofKind === "merge")
+            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.SingleProfile single = 4; */
+        if (message.options.oneofKind === "single")
+            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
+        if (message.reportType !== 0)
+            writer.tag(5, WireType.Varint).int32(message.reportType);
+        /* repeated string group_by = 6; */
+        for (let i = 0; i < message.groupBy.length; i++)
+            writer.tag(6, WireType.LengthDelimited).string(message.groupBy[i]);
+        /* parca.query.v1alpha1.SourceReference source_reference = 7; */
+        if (message.sourceReference)
+            SourceReference.internalBinaryWrite(message.sourceReference, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* float node_trim_threshold = 8; */
+        if (message.nodeTrimThreshold !== 0)
+            writer.tag(8, WireType.Bit32).float(message.nodeTrimThreshold);
+        /* uint32 max_depth = 9; */
+        if (message.maxDepth !== 0)
+            writer.tag(9, WireType.Varint).uint32(message.maxDepth);
+        /* bool collapse_trimmed = 10; */
+        if (message.collapseTrimmed !== false)
+            writer.tag(10, WireType.Varint).bool(message.collapseTrimmed);
+        /* string focus = 11; */
+        if (message.focus !== "")
+            writer.tag(11, WireType.LengthDelimited).string(message.focus);
+        /* string ignore = 12; */
+        if (message.ignore !== "")
+            writer.tag(12, WireType.LengthDelimited).string(message.ignore);
+        /* string hide = 13; */
+        if (message.hide !== "")
+            writer.tag(13, WireType.LengthDelimited).string(message.hide);
+        /* string show = 14; */
+        if (message.show !== "")
+            writer.tag(14, WireType.LengthDelimited).string(message.show);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
 }
 /**
- * ShareProfileResponse represents the shared link of a profile
- *
- * @generated from protobuf message parca.query.v1alpha1.ShareProfileResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRequest
  */
-export interface ShareProfileResponse {
-    /**
-     * link to access the profile
-     *
-     * @generated from protobuf field: string link = 1;
-     */
-    link: string;
-}
+export const QueryRequest = new QueryRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
+class SourceReference$Type extends MessageType<SourceReference> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileTypesRequest", []);
+        super("parca.query.v1alpha1.SourceReference", [
+            { no: 1, name: "function", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
     }
-    create(value?: PartialMessage<ProfileTypesRequest>): ProfileTypesRequest {
-        const message = {};
+    create(value?: PartialMessage<SourceReference>): SourceReference {
+        const message = { function: "", filename: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileTypesRequest>(this, message, value);
+            reflectionMergePartial<SourceReference>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesRequest): ProfileTypesRequest {
-        return target ?? this.create();
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceReference): SourceReference {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string function */ 1:
+                    message.function = reader.string();
+                    break;
+                case /* string filename */ 2:
+                    message.filename = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
     }
-    internalBinaryWrite(message: ProfileTypesRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+    internalBinaryWrite(message: SourceReference, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string function = 1; */
+        if (message.function !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.function);
+        /* string filename = 2; */
+        if (message.filename !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.filename);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -839,30 +2264,42 @@ class ProfileTypesRequest$Type extends MessageType<ProfileTypesRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceReference
  */
-export const ProfileTypesRequest = new ProfileTypesRequest$Type();
+export const SourceReference = new SourceReference$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
+class Top$Type extends MessageType<Top> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileTypesResponse", [
-            { no: 1, name: "types", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ProfileType }
+        super("parca.query.v1alpha1.Top", [
+            { no: 1, name: "list", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => TopNode },
+            { no: 2, name: "reported", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "total", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileTypesResponse>): ProfileTypesResponse {
-        const message = { types: [] };
+    create(value?: PartialMessage<Top>): Top {
+        const message = { list: [], reported: 0, total: 0, unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileTypesResponse>(this, message, value);
+            reflectionMergePartial<Top>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileTypesResponse): ProfileTypesResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Top): Top {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.ProfileType types */ 1:
-                    message.types.push(ProfileType.internalBinaryRead(reader, reader.uint32(), options));
+                case /* repeated parca.query.v1alpha1.TopNode list */ 1:
+                    message.list.push(TopNode.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int32 reported */ 2:
+                    message.reported = reader.int32();
+                    break;
+                case /* int32 total */ 3:
+                    message.total = reader.int32();
+                    break;
+                case /* string unit */ 4:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -875,10 +2312,19 @@ class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileTypesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.ProfileType types = 1; */
-        for (let i = 0; i < message.types.length; i++)
-            ProfileType.internalBinaryWrite(message.types[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Top, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.TopNode list = 1; */
+        for (let i = 0; i < message.list.length; i++)
+            TopNode.internalBinaryWrite(message.list[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int32 reported = 2; */
+        if (message.reported !== 0)
+            writer.tag(2, WireType.Varint).int32(message.reported);
+        /* int32 total = 3; */
+        if (message.total !== 0)
+            writer.tag(3, WireType.Varint).int32(message.total);
+        /* string unit = 4; */
+        if (message.unit !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -886,50 +2332,42 @@ class ProfileTypesResponse$Type extends MessageType<ProfileTypesResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileTypesResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Top
  */
-export const ProfileTypesResponse = new ProfileTypesResponse$Type();
+export const Top = new Top$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileType$Type extends MessageType<ProfileType> {
+class TopNode$Type extends MessageType<TopNode> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileType", [
-            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "sample_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "sample_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "period_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 5, name: "period_unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 6, name: "delta", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        super("parca.query.v1alpha1.TopNode", [
+            { no: 1, name: "meta", kind: "message", T: () => TopNodeMeta },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileType>): ProfileType {
-        const message = { name: "", sampleType: "", sampleUnit: "", periodType: "", periodUnit: "", delta: false };
+    create(value?: PartialMessage<TopNode>): TopNode {
+        const message = { cumulative: "0", flat: "0", diff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileType>(this, message, value);
+            reflectionMergePartial<TopNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileType): ProfileType {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNode): TopNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string name */ 1:
-                    message.name = reader.string();
-                    break;
-                case /* string sample_type */ 2:
-                    message.sampleType = reader.string();
-                    break;
-                case /* string sample_unit */ 3:
-                    message.sampleUnit = reader.string();
+                case /* parca.query.v1alpha1.TopNodeMeta meta */ 1:
+                    message.meta = TopNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
                     break;
-                case /* string period_type */ 4:
-                    message.periodType = reader.string();
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toString();
                     break;
-                case /* string period_unit */ 5:
-                    message.periodUnit = reader.string();
+                case /* int64 flat */ 3:
+                    message.flat = reader.int64().toString();
                     break;
-                case /* bool delta */ 6:
-                    message.delta = reader.bool();
+                case /* int64 diff */ 4:
+                    message.diff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -942,25 +2380,19 @@ class ProfileType$Type extends MessageType<ProfileType> {
         }
         return message;
     }
-    internalBinaryWrite(message: ProfileType, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string name = 1; */
-        if (message.name !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.name);
-        /* string sample_type = 2; */
-        if (message.sampleType !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.sampleType);
-        /* string sample_unit = 3; */
-        if (message.sampleUnit !== "")
-            writer.tag(3, WireType.LengthDelimited).string(message.sampleUnit);
-        /* string period_type = 4; */
-        if (message.periodType !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.periodType);
-        /* string period_unit = 5; */
-        if (message.periodUnit !== "")
-            writer.tag(5, WireType.LengthDelimited).string(message.periodUnit);
-        /* bool delta = 6; */
-        if (message.delta !== false)
-            writer.tag(6, WireType.Varint).bool(message.delta);
+    internalBinaryWrite(message: TopNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.TopNodeMeta meta = 1; */
+        if (message.meta)
+            TopNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== "0")
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 3; */
+        if (message.flat !== "0")
+            writer.tag(3, WireType.Varint).int64(message.flat);
+        /* int64 diff = 4; */
+        if (message.diff !== "0")
+            writer.tag(4, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -968,42 +2400,42 @@ class ProfileType$Type extends MessageType<ProfileType> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileType
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopNode
  */
-export const ProfileType = new ProfileType$Type();
+export const TopNode = new TopNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
+class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRangeRequest", [
-            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp },
-            { no: 4, name: "limit", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        super("parca.query.v1alpha1.TopNodeMeta", [
+            { no: 1, name: "location", kind: "message", T: () => Location },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "function", kind: "message", T: () => Function },
+            { no: 4, name: "line", kind: "message", T: () => Line }
         ]);
     }
-    create(value?: PartialMessage<QueryRangeRequest>): QueryRangeRequest {
-        const message = { query: "", limit: 0 };
+    create(value?: PartialMessage<TopNodeMeta>): TopNodeMeta {
+        const message = {};
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<QueryRangeRequest>(this, message, value);
+            reflectionMergePartial<TopNodeMeta>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeRequest): QueryRangeRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNodeMeta): TopNodeMeta {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string query */ 1:
-                    message.query = reader.string();
+                case /* parca.metastore.v1alpha1.Location location */ 1:
+                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
                     break;
-                case /* google.protobuf.Timestamp start */ 2:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* google.protobuf.Timestamp end */ 3:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                case /* parca.metastore.v1alpha1.Function function */ 3:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
                     break;
-                case /* uint32 limit */ 4:
-                    message.limit = reader.uint32();
+                case /* parca.metastore.v1alpha1.Line line */ 4:
+                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1016,19 +2448,19 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRangeRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string query = 1; */
-        if (message.query !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.query);
-        /* google.protobuf.Timestamp start = 2; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* google.protobuf.Timestamp end = 3; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* uint32 limit = 4; */
-        if (message.limit !== 0)
-            writer.tag(4, WireType.Varint).uint32(message.limit);
+    internalBinaryWrite(message: TopNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Location location = 1; */
+        if (message.location)
+            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Function function = 3; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Line line = 4; */
+        if (message.line)
+            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1036,30 +2468,42 @@ class QueryRangeRequest$Type extends MessageType<QueryRangeRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.TopNodeMeta
  */
-export const QueryRangeRequest = new QueryRangeRequest$Type();
+export const TopNodeMeta = new TopNodeMeta$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
+class Callgraph$Type extends MessageType<Callgraph> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRangeResponse", [
-            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSeries }
+        super("parca.query.v1alpha1.Callgraph", [
+            { no: 1, name: "nodes", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CallgraphNode },
+            { no: 2, name: "edges", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CallgraphEdge },
+            { no: 3, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<QueryRangeResponse>): QueryRangeResponse {
-        const message = { series: [] };
+    create(value?: PartialMessage<Callgraph>): Callgraph {
+        const message = { nodes: [], edges: [], cumulative: "0", unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<QueryRangeResponse>(this, message, value);
+            reflectionMergePartial<Callgraph>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRangeResponse): QueryRangeResponse {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Callgraph): Callgraph {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.MetricsSeries series */ 1:
-                    message.series.push(MetricsSeries.internalBinaryRead(reader, reader.uint32(), options));
+                case /* repeated parca.query.v1alpha1.CallgraphNode nodes */ 1:
+                    message.nodes.push(CallgraphNode.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated parca.query.v1alpha1.CallgraphEdge edges */ 2:
+                    message.edges.push(CallgraphEdge.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int64 cumulative */ 3:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* string unit */ 4:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1072,10 +2516,19 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRangeResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.MetricsSeries series = 1; */
-        for (let i = 0; i < message.series.length; i++)
-            MetricsSeries.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Callgraph, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.CallgraphNode nodes = 1; */
+        for (let i = 0; i < message.nodes.length; i++)
+            CallgraphNode.internalBinaryWrite(message.nodes[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* repeated parca.query.v1alpha1.CallgraphEdge edges = 2; */
+        for (let i = 0; i < message.edges.length; i++)
+            CallgraphEdge.internalBinaryWrite(message.edges[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 3; */
+        if (message.cumulative !== "0")
+            writer.tag(3, WireType.Varint).int64(message.cumulative);
+        /* string unit = 4; */
+        if (message.unit !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1083,42 +2536,50 @@ class QueryRangeResponse$Type extends MessageType<QueryRangeResponse> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRangeResponse
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Callgraph
  */
-export const QueryRangeResponse = new QueryRangeResponse$Type();
+export const Callgraph = new Callgraph$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MetricsSeries$Type extends MessageType<MetricsSeries> {
+class CallgraphNode$Type extends MessageType<CallgraphNode> {
     constructor() {
-        super("parca.query.v1alpha1.MetricsSeries", [
-            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
-            { no: 2, name: "samples", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => MetricsSample },
-            { no: 3, name: "period_type", kind: "message", T: () => ValueType },
-            { no: 4, name: "sample_type", kind: "message", T: () => ValueType }
+        super("parca.query.v1alpha1.CallgraphNode", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "meta", kind: "message", T: () => CallgraphNodeMeta },
+            { no: 3, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "flat_diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<MetricsSeries>): MetricsSeries {
-        const message = { samples: [] };
+    create(value?: PartialMessage<CallgraphNode>): CallgraphNode {
+        const message = { id: "", cumulative: "0", flat: "0", diff: "0", flatDiff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<MetricsSeries>(this, message, value);
+            reflectionMergePartial<CallgraphNode>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSeries): MetricsSeries {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNode): CallgraphNode {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
-                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                case /* string id */ 1:
+                    message.id = reader.string();
                     break;
-                case /* repeated parca.query.v1alpha1.MetricsSample samples */ 2:
-                    message.samples.push(MetricsSample.internalBinaryRead(reader, reader.uint32(), options));
+                case /* parca.query.v1alpha1.CallgraphNodeMeta meta */ 2:
+                    message.meta = CallgraphNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
                     break;
-                case /* parca.query.v1alpha1.ValueType period_type */ 3:
-                    message.periodType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.periodType);
+                case /* int64 cumulative */ 3:
+                    message.cumulative = reader.int64().toString();
                     break;
-                case /* parca.query.v1alpha1.ValueType sample_type */ 4:
-                    message.sampleType = ValueType.internalBinaryRead(reader, reader.uint32(), options, message.sampleType);
+                case /* int64 flat */ 4:
+                    message.flat = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 5:
+                    message.diff = reader.int64().toString();
+                    break;
+                case /* int64 flat_diff */ 6:
+                    message.flatDiff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1131,19 +2592,25 @@ class MetricsSeries$Type extends MessageType<MetricsSeries> {
         }
         return message;
     }
-    internalBinaryWrite(message: MetricsSeries, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
-        if (message.labelset)
-            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* repeated parca.query.v1alpha1.MetricsSample samples = 2; */
-        for (let i = 0; i < message.samples.length; i++)
-            MetricsSample.internalBinaryWrite(message.samples[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ValueType period_type = 3; */
-        if (message.periodType)
-            ValueType.internalBinaryWrite(message.periodType, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ValueType sample_type = 4; */
-        if (message.sampleType)
-            ValueType.internalBinaryWrite(message.sampleType, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: CallgraphNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* parca.query.v1alpha1.CallgraphNodeMeta meta = 2; */
+        if (message.meta)
+            CallgraphNodeMeta.internalBinaryWrite(message.meta, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 3; */
+        if (message.cumulative !== "0")
+            writer.tag(3, WireType.Varint).int64(message.cumulative);
+        /* int64 flat = 4; */
+        if (message.flat !== "0")
+            writer.tag(4, WireType.Varint).int64(message.flat);
+        /* int64 diff = 5; */
+        if (message.diff !== "0")
+            writer.tag(5, WireType.Varint).int64(message.diff);
+        /* int64 flat_diff = 6; */
+        if (message.flatDiff !== "0")
+            writer.tag(6, WireType.Varint).int64(message.flatDiff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1151,34 +2618,42 @@ class MetricsSeries$Type extends MessageType<MetricsSeries> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSeries
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNode
  */
-export const MetricsSeries = new MetricsSeries$Type();
+export const CallgraphNode = new CallgraphNode$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MetricsSample$Type extends MessageType<MetricsSample> {
+class CallgraphNodeMeta$Type extends MessageType<CallgraphNodeMeta> {
     constructor() {
-        super("parca.query.v1alpha1.MetricsSample", [
-            { no: 1, name: "timestamp", kind: "message", T: () => Timestamp },
-            { no: 2, name: "value", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+        super("parca.query.v1alpha1.CallgraphNodeMeta", [
+            { no: 1, name: "location", kind: "message", T: () => Location },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "function", kind: "message", T: () => Function },
+            { no: 4, name: "line", kind: "message", T: () => Line }
         ]);
     }
-    create(value?: PartialMessage<MetricsSample>): MetricsSample {
-        const message = { value: "0" };
+    create(value?: PartialMessage<CallgraphNodeMeta>): CallgraphNodeMeta {
+        const message = {};
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<MetricsSample>(this, message, value);
+            reflectionMergePartial<CallgraphNodeMeta>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MetricsSample): MetricsSample {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphNodeMeta): CallgraphNodeMeta {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* google.protobuf.Timestamp timestamp */ 1:
-                    message.timestamp = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.timestamp);
+                case /* parca.metastore.v1alpha1.Location location */ 1:
+                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
                     break;
-                case /* int64 value */ 2:
-                    message.value = reader.int64().toString();
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
+                    break;
+                case /* parca.metastore.v1alpha1.Function function */ 3:
+                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                    break;
+                case /* parca.metastore.v1alpha1.Line line */ 4:
+                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1191,13 +2666,19 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
         }
         return message;
     }
-    internalBinaryWrite(message: MetricsSample, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* google.protobuf.Timestamp timestamp = 1; */
-        if (message.timestamp)
-            Timestamp.internalBinaryWrite(message.timestamp, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 value = 2; */
-        if (message.value !== "0")
-            writer.tag(2, WireType.Varint).int64(message.value);
+    internalBinaryWrite(message: CallgraphNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.metastore.v1alpha1.Location location = 1; */
+        if (message.location)
+            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Function function = 3; */
+        if (message.function)
+            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* parca.metastore.v1alpha1.Line line = 4; */
+        if (message.line)
+            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1205,38 +2686,46 @@ class MetricsSample$Type extends MessageType<MetricsSample> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MetricsSample
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphNodeMeta
  */
-export const MetricsSample = new MetricsSample$Type();
+export const CallgraphNodeMeta = new CallgraphNodeMeta$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class MergeProfile$Type extends MessageType<MergeProfile> {
+class CallgraphEdge$Type extends MessageType<CallgraphEdge> {
     constructor() {
-        super("parca.query.v1alpha1.MergeProfile", [
-            { no: 1, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "start", kind: "message", T: () => Timestamp },
-            { no: 3, name: "end", kind: "message", T: () => Timestamp }
+        super("parca.query.v1alpha1.CallgraphEdge", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "source", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "target", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<MergeProfile>): MergeProfile {
-        const message = { query: "" };
+    create(value?: PartialMessage<CallgraphEdge>): CallgraphEdge {
+        const message = { id: "", source: "", target: "", cumulative: "0", diff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<MergeProfile>(this, message, value);
+            reflectionMergePartial<CallgraphEdge>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MergeProfile): MergeProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CallgraphEdge): CallgraphEdge {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* string query */ 1:
-                    message.query = reader.string();
+                case /* string id */ 1:
+                    message.id = reader.string();
                     break;
-                case /* google.protobuf.Timestamp start */ 2:
-                    message.start = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.start);
+                case /* string source */ 2:
+                    message.source = reader.string();
                     break;
-                case /* google.protobuf.Timestamp end */ 3:
-                    message.end = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.end);
+                case /* string target */ 3:
+                    message.target = reader.string();
+                    break;
+                case /* int64 cumulative */ 4:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 5:
+                    message.diff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1249,16 +2738,22 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: MergeProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* string query = 1; */
-        if (message.query !== "")
-            writer.tag(1, WireType.LengthDelimited).string(message.query);
-        /* google.protobuf.Timestamp start = 2; */
-        if (message.start)
-            Timestamp.internalBinaryWrite(message.start, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* google.protobuf.Timestamp end = 3; */
-        if (message.end)
-            Timestamp.internalBinaryWrite(message.end, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: CallgraphEdge, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* string source = 2; */
+        if (message.source !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.source);
+        /* string target = 3; */
+        if (message.target !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.target);
+        /* int64 cumulative = 4; */
+        if (message.cumulative !== "0")
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 5; */
+        if (message.diff !== "0")
+            writer.tag(5, WireType.Varint).int64(message.diff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1266,34 +2761,38 @@ class MergeProfile$Type extends MessageType<MergeProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.MergeProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.CallgraphEdge
  */
-export const MergeProfile = new MergeProfile$Type();
+export const CallgraphEdge = new CallgraphEdge$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class SingleProfile$Type extends MessageType<SingleProfile> {
+class Source$Type extends MessageType<Source> {
     constructor() {
-        super("parca.query.v1alpha1.SingleProfile", [
-            { no: 1, name: "time", kind: "message", T: () => Timestamp },
-            { no: 2, name: "query", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.Source", [
+            { no: 1, name: "files", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => SourceFile },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<SingleProfile>): SingleProfile {
-        const message = { query: "" };
+    create(value?: PartialMessage<Source>): Source {
+        const message = { files: [], cumulative: "0", unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<SingleProfile>(this, message, value);
+            reflectionMergePartial<Source>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SingleProfile): SingleProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Source): Source {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* google.protobuf.Timestamp time */ 1:
-                    message.time = Timestamp.internalBinaryRead(reader, reader.uint32(), options, message.time);
+                case /* repeated parca.query.v1alpha1.SourceFile files */ 1:
+                    message.files.push(SourceFile.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* string query */ 2:
-                    message.query = reader.string();
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* string unit */ 3:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1306,13 +2805,16 @@ class SingleProfile$Type extends MessageType<SingleProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: SingleProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* google.protobuf.Timestamp time = 1; */
-        if (message.time)
-            Timestamp.internalBinaryWrite(message.time, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* string query = 2; */
-        if (message.query !== "")
-            writer.tag(2, WireType.LengthDelimited).string(message.query);
+    internalBinaryWrite(message: Source, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.SourceFile files = 1; */
+        for (let i = 0; i < message.files.length; i++)
+            SourceFile.internalBinaryWrite(message.files[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== "0")
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* string unit = 3; */
+        if (message.unit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1320,34 +2822,34 @@ class SingleProfile$Type extends MessageType<SingleProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.SingleProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Source
  */
-export const SingleProfile = new SingleProfile$Type();
+export const Source = new Source$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class DiffProfile$Type extends MessageType<DiffProfile> {
+class SourceFile$Type extends MessageType<SourceFile> {
     constructor() {
-        super("parca.query.v1alpha1.DiffProfile", [
-            { no: 1, name: "a", kind: "message", T: () => ProfileDiffSelection },
-            { no: 2, name: "b", kind: "message", T: () => ProfileDiffSelection }
+        super("parca.query.v1alpha1.SourceFile", [
+            { no: 1, name: "filename", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "lines", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => SourceLine }
         ]);
     }
-    create(value?: PartialMessage<DiffProfile>): DiffProfile {
-        const message = {};
+    create(value?: PartialMessage<SourceFile>): SourceFile {
+        const message = { filename: "", lines: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<DiffProfile>(this, message, value);
+            reflectionMergePartial<SourceFile>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DiffProfile): DiffProfile {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceFile): SourceFile {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.ProfileDiffSelection a */ 1:
-                    message.a = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.a);
+                case /* string filename */ 1:
+                    message.filename = reader.string();
                     break;
-                case /* parca.query.v1alpha1.ProfileDiffSelection b */ 2:
-                    message.b = ProfileDiffSelection.internalBinaryRead(reader, reader.uint32(), options, message.b);
+                case /* repeated parca.query.v1alpha1.SourceLine lines */ 2:
+                    message.lines.push(SourceLine.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1360,13 +2862,13 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
         }
         return message;
     }
-    internalBinaryWrite(message: DiffProfile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.ProfileDiffSelection a = 1; */
-        if (message.a)
-            ProfileDiffSelection.internalBinaryWrite(message.a, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.ProfileDiffSelection b = 2; */
-        if (message.b)
-            ProfileDiffSelection.internalBinaryWrite(message.b, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: SourceFile, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string filename = 1; */
+        if (message.filename !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.filename);
+        /* repeated parca.query.v1alpha1.SourceLine lines = 2; */
+        for (let i = 0; i < message.lines.length; i++)
+            SourceLine.internalBinaryWrite(message.lines[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1374,44 +2876,46 @@ class DiffProfile$Type extends MessageType<DiffProfile> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.DiffProfile
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceFile
  */
-export const DiffProfile = new DiffProfile$Type();
+export const SourceFile = new SourceFile$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
+class SourceLine$Type extends MessageType<SourceLine> {
     constructor() {
-        super("parca.query.v1alpha1.ProfileDiffSelection", [
-            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.ProfileDiffSelection.Mode", ProfileDiffSelection_Mode, "MODE_"] },
-            { no: 2, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
-            { no: 3, name: "single", kind: "message", oneof: "options", T: () => SingleProfile }
+        super("parca.query.v1alpha1.SourceLine", [
+            { no: 1, name: "line", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 2, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "flat_diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<ProfileDiffSelection>): ProfileDiffSelection {
-        const message = { mode: 0, options: { oneofKind: undefined } };
+    create(value?: PartialMessage<SourceLine>): SourceLine {
+        const message = { line: "0", flat: "0", cumulative: "0", diff: "0", flatDiff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<ProfileDiffSelection>(this, message, value);
+            reflectionMergePartial<SourceLine>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ProfileDiffSelection): ProfileDiffSelection {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SourceLine): SourceLine {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode */ 1:
-                    message.mode = reader.int32();
+                case /* int64 line */ 1:
+                    message.line = reader.int64().toString();
                     break;
-                case /* parca.query.v1alpha1.MergeProfile merge */ 2:
-                    message.options = {
-                        oneofKind: "merge",
-                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
-                    };
+                case /* int64 flat */ 2:
+                    message.flat = reader.int64().toString();
                     break;
-                case /* parca.query.v1alpha1.SingleProfile single */ 3:
-                    message.options = {
-                        oneofKind: "single",
-                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
-                    };
+                case /* int64 cumulative */ 3:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 4:
+                    message.diff = reader.int64().toString();
+                    break;
+                case /* int64 flat_diff */ 5:
+                    message.flatDiff = reader.int64().toString();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1420,20 +2924,26 @@ class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
                     let d = reader.skip(wireType);
                     if (u !== false)
                         (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
-            }
-        }
-        return message;
-    }
-    internalBinaryWrite(message: ProfileDiffSelection, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.ProfileDiffSelection.Mode mode = 1; */
-        if (message.mode !== 0)
-            writer.tag(1, WireType.Varint).int32(message.mode);
-        /* parca.query.v1alpha1.MergeProfile merge = 2; */
-        if (message.options.oneofKind === "merge")
-            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.SingleProfile single = 3; */
-        if (message.options.oneofKind === "single")
-            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SourceLine, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* int64 line = 1; */
+        if (message.line !== "0")
+            writer.tag(1, WireType.Varint).int64(message.line);
+        /* int64 flat = 2; */
+        if (message.flat !== "0")
+            writer.tag(2, WireType.Varint).int64(message.flat);
+        /* int64 cumulative = 3; */
+        if (message.cumulative !== "0")
+            writer.tag(3, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 4; */
+        if (message.diff !== "0")
+            writer.tag(4, WireType.Varint).int64(message.diff);
+        /* int64 flat_diff = 5; */
+        if (message.flatDiff !== "0")
+            writer.tag(5, WireType.Varint).int64(message.flatDiff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1441,55 +2951,38 @@ class ProfileDiffSelection$Type extends MessageType<ProfileDiffSelection> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.ProfileDiffSelection
+ * @generated MessageType for protobuf message parca.query.v1alpha1.SourceLine
  */
-export const ProfileDiffSelection = new ProfileDiffSelection$Type();
+export const SourceLine = new SourceLine$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class QueryRequest$Type extends MessageType<QueryRequest> {
+class Disassembly$Type extends MessageType<Disassembly> {
     constructor() {
-        super("parca.query.v1alpha1.QueryRequest", [
-            { no: 1, name: "mode", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.Mode", QueryRequest_Mode, "MODE_"] },
-            { no: 2, name: "diff", kind: "message", oneof: "options", T: () => DiffProfile },
-            { no: 3, name: "merge", kind: "message", oneof: "options", T: () => MergeProfile },
-            { no: 4, name: "single", kind: "message", oneof: "options", T: () => SingleProfile },
-            { no: 5, name: "report_type", kind: "enum", T: () => ["parca.query.v1alpha1.QueryRequest.ReportType", QueryRequest_ReportType, "REPORT_TYPE_"] }
+        super("parca.query.v1alpha1.Disassembly", [
+            { no: 1, name: "functions", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => DisassembledFunction },
+            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<QueryRequest>): QueryRequest {
-        const message = { mode: 0, options: { oneofKind: undefined }, reportType: 0 };
+    create(value?: PartialMessage<Disassembly>): Disassembly {
+        const message = { functions: [], cumulative: "0", unit: "" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<QueryRequest>(this, message, value);
+            reflectionMergePartial<Disassembly>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: QueryRequest): QueryRequest {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Disassembly): Disassembly {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.QueryRequest.Mode mode */ 1:
-                    message.mode = reader.int32();
-                    break;
-                case /* parca.query.v1alpha1.DiffProfile diff */ 2:
-                    message.options = {
-                        oneofKind: "diff",
-                        diff: DiffProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).diff)
-                    };
-                    break;
-                case /* parca.query.v1alpha1.MergeProfile merge */ 3:
-                    message.options = {
-                        oneofKind: "merge",
-                        merge: MergeProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).merge)
-                    };
+                case /* repeated parca.query.v1alpha1.DisassembledFunction functions */ 1:
+                    message.functions.push(DisassembledFunction.internalBinaryRead(reader, reader.uint32(), options));
                     break;
-                case /* parca.query.v1alpha1.SingleProfile single */ 4:
-                    message.options = {
-                        oneofKind: "single",
-                        single: SingleProfile.internalBinaryRead(reader, reader.uint32(), options, (message.options as any).single)
-                    };
+                case /* int64 cumulative */ 2:
+                    message.cumulative = reader.int64().toString();
                     break;
-                case /* parca.query.v1alpha1.QueryRequest.ReportType report_type */ 5:
-                    message.reportType = reader.int32();
+                case /* string unit */ 3:
+                    message.unit = reader.string();
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1502,22 +2995,16 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
         }
         return message;
     }
-    internalBinaryWrite(message: QueryRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.QueryRequest.Mode mode = 1; */
-        if (message.mode !== 0)
-            writer.tag(1, WireType.Varint).int32(message.mode);
-        /* parca.query.v1alpha1.DiffProfile diff = 2; */
-        if (message.options.oneofKind === "diff")
-            DiffProfile.internalBinaryWrite(message.options.diff, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.MergeProfile merge = 3; */
-        if (message.options.oneofKind === "merge")
-            MergeProfile.internalBinaryWrite(message.options.merge, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.SingleProfile single = 4; */
-        if (message.options.oneofKind === "single")
-            SingleProfile.internalBinaryWrite(message.options.single, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
-        /* parca.query.v1alpha1.QueryRequest.ReportType report_type = 5; */
-        if (message.reportType !== 0)
-            writer.tag(5, WireType.Varint).int32(message.reportType);
+    internalBinaryWrite(message: Disassembly, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.DisassembledFunction functions = 1; */
+        for (let i = 0; i < message.functions.length; i++)
+            DisassembledFunction.internalBinaryWrite(message.functions[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* int64 cumulative = 2; */
+        if (message.cumulative !== "0")
+            writer.tag(2, WireType.Varint).int64(message.cumulative);
+        /* string unit = 3; */
+        if (message.unit !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.unit);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1525,42 +3012,46 @@ class QueryRequest$Type extends MessageType<QueryRequest> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.QueryRequest
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Disassembly
  */
-export const QueryRequest = new QueryRequest$Type();
+export const Disassembly = new Disassembly$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class Top$Type extends MessageType<Top> {
+class DisassembledFunction$Type extends MessageType<DisassembledFunction> {
     constructor() {
-        super("parca.query.v1alpha1.Top", [
-            { no: 1, name: "list", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => TopNode },
-            { no: 2, name: "reported", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 3, name: "total", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 4, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        super("parca.query.v1alpha1.DisassembledFunction", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
+            { no: 3, name: "start", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 4, name: "end", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 5, name: "instructions", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => DisassembledInstruction }
         ]);
     }
-    create(value?: PartialMessage<Top>): Top {
-        const message = { list: [], reported: 0, total: 0, unit: "" };
+    create(value?: PartialMessage<DisassembledFunction>): DisassembledFunction {
+        const message = { name: "", start: "0", end: "0", instructions: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<Top>(this, message, value);
+            reflectionMergePartial<DisassembledFunction>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Top): Top {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DisassembledFunction): DisassembledFunction {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated parca.query.v1alpha1.TopNode list */ 1:
-                    message.list.push(TopNode.internalBinaryRead(reader, reader.uint32(), options));
+                case /* string name */ 1:
+                    message.name = reader.string();
                     break;
-                case /* int32 reported */ 2:
-                    message.reported = reader.int32();
+                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
+                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
                     break;
-                case /* int32 total */ 3:
-                    message.total = reader.int32();
+                case /* uint64 start */ 3:
+                    message.start = reader.uint64().toString();
                     break;
-                case /* string unit */ 4:
-                    message.unit = reader.string();
+                case /* uint64 end */ 4:
+                    message.end = reader.uint64().toString();
+                    break;
+                case /* repeated parca.query.v1alpha1.DisassembledInstruction instructions */ 5:
+                    message.instructions.push(DisassembledInstruction.internalBinaryRead(reader, reader.uint32(), options));
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1573,19 +3064,22 @@ class Top$Type extends MessageType<Top> {
         }
         return message;
     }
-    internalBinaryWrite(message: Top, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* repeated parca.query.v1alpha1.TopNode list = 1; */
-        for (let i = 0; i < message.list.length; i++)
-            TopNode.internalBinaryWrite(message.list[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int32 reported = 2; */
-        if (message.reported !== 0)
-            writer.tag(2, WireType.Varint).int32(message.reported);
-        /* int32 total = 3; */
-        if (message.total !== 0)
-            writer.tag(3, WireType.Varint).int32(message.total);
-        /* string unit = 4; */
-        if (message.unit !== "")
-            writer.tag(4, WireType.LengthDelimited).string(message.unit);
+    internalBinaryWrite(message: DisassembledFunction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string name = 1; */
+        if (message.name !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.name);
+        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
+        if (message.mapping)
+            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* uint64 start = 3; */
+        if (message.start !== "0")
+            writer.tag(3, WireType.Varint).uint64(message.start);
+        /* uint64 end = 4; */
+        if (message.end !== "0")
+            writer.tag(4, WireType.Varint).uint64(message.end);
+        /* repeated parca.query.v1alpha1.DisassembledInstruction instructions = 5; */
+        for (let i = 0; i < message.instructions.length; i++)
+            DisassembledInstruction.internalBinaryWrite(message.instructions[i], writer.tag(5, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1593,43 +3087,51 @@ class Top$Type extends MessageType<Top> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.Top
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DisassembledFunction
  */
-export const Top = new Top$Type();
+export const DisassembledFunction = new DisassembledFunction$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TopNode$Type extends MessageType<TopNode> {
+class DisassembledInstruction$Type extends MessageType<DisassembledInstruction> {
     constructor() {
-        super("parca.query.v1alpha1.TopNode", [
-            { no: 1, name: "meta", kind: "message", T: () => TopNodeMeta },
-            { no: 2, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+        super("parca.query.v1alpha1.DisassembledInstruction", [
+            { no: 1, name: "address", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 2, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 3, name: "flat", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
-            { no: 4, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
+            { no: 4, name: "cumulative", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 5, name: "diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
+            { no: 6, name: "flat_diff", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
-    create(value?: PartialMessage<TopNode>): TopNode {
-        const message = { cumulative: "0", flat: "0", diff: "0" };
+    create(value?: PartialMessage<DisassembledInstruction>): DisassembledInstruction {
+        const message = { address: "0", text: "", flat: "0", cumulative: "0", diff: "0", flatDiff: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<TopNode>(this, message, value);
+            reflectionMergePartial<DisassembledInstruction>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNode): TopNode {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: DisassembledInstruction): DisassembledInstruction {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.query.v1alpha1.TopNodeMeta meta */ 1:
-                    message.meta = TopNodeMeta.internalBinaryRead(reader, reader.uint32(), options, message.meta);
+                case /* uint64 address */ 1:
+                    message.address = reader.uint64().toString();
                     break;
-                case /* int64 cumulative */ 2:
-                    message.cumulative = reader.int64().toString();
+                case /* string text */ 2:
+                    message.text = reader.string();
                     break;
                 case /* int64 flat */ 3:
                     message.flat = reader.int64().toString();
                     break;
-                case /* int64 diff */ 4:
+                case /* int64 cumulative */ 4:
+                    message.cumulative = reader.int64().toString();
+                    break;
+                case /* int64 diff */ 5:
                     message.diff = reader.int64().toString();
                     break;
+                case /* int64 flat_diff */ 6:
+                    message.flatDiff = reader.int64().toString();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1641,19 +3143,25 @@ class TopNode$Type extends MessageType<TopNode> {
         }
         return message;
     }
-    internalBinaryWrite(message: TopNode, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.query.v1alpha1.TopNodeMeta meta = 1; */
-        if (message.meta)
-            TopNodeMeta.internalBinaryWrite(message.meta, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* int64 cumulative = 2; */
-        if (message.cumulative !== "0")
-            writer.tag(2, WireType.Varint).int64(message.cumulative);
+    internalBinaryWrite(message: DisassembledInstruction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* uint64 address = 1; */
+        if (message.address !== "0")
+            writer.tag(1, WireType.Varint).uint64(message.address);
+        /* string text = 2; */
+        if (message.text !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.text);
         /* int64 flat = 3; */
         if (message.flat !== "0")
             writer.tag(3, WireType.Varint).int64(message.flat);
-        /* int64 diff = 4; */
+        /* int64 cumulative = 4; */
+        if (message.cumulative !== "0")
+            writer.tag(4, WireType.Varint).int64(message.cumulative);
+        /* int64 diff = 5; */
         if (message.diff !== "0")
-            writer.tag(4, WireType.Varint).int64(message.diff);
+            writer.tag(5, WireType.Varint).int64(message.diff);
+        /* int64 flat_diff = 6; */
+        if (message.flatDiff !== "0")
+            writer.tag(6, WireType.Varint).int64(message.flatDiff);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1661,42 +3169,34 @@ class TopNode$Type extends MessageType<TopNode> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.TopNode
+ * @generated MessageType for protobuf message parca.query.v1alpha1.DisassembledInstruction
  */
-export const TopNode = new TopNode$Type();
+export const DisassembledInstruction = new DisassembledInstruction$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
+class Sandwich$Type extends MessageType<Sandwich> {
     constructor() {
-        super("parca.query.v1alpha1.TopNodeMeta", [
-            { no: 1, name: "location", kind: "message", T: () => Location },
-            { no: 2, name: "mapping", kind: "message", T: () => Mapping },
-            { no: 3, name: "function", kind: "message", T: () => Function },
-            { no: 4, name: "line", kind: "message", T: () => Line }
+        super("parca.query.v1alpha1.Sandwich", [
+            { no: 1, name: "callers", kind: "message", T: () => Flamegraph },
+            { no: 2, name: "callees", kind: "message", T: () => Flamegraph }
         ]);
     }
-    create(value?: PartialMessage<TopNodeMeta>): TopNodeMeta {
+    create(value?: PartialMessage<Sandwich>): Sandwich {
         const message = {};
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
-            reflectionMergePartial<TopNodeMeta>(this, message, value);
+            reflectionMergePartial<Sandwich>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TopNodeMeta): TopNodeMeta {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Sandwich): Sandwich {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* parca.metastore.v1alpha1.Location location */ 1:
-                    message.location = Location.internalBinaryRead(reader, reader.uint32(), options, message.location);
-                    break;
-                case /* parca.metastore.v1alpha1.Mapping mapping */ 2:
-                    message.mapping = Mapping.internalBinaryRead(reader, reader.uint32(), options, message.mapping);
-                    break;
-                case /* parca.metastore.v1alpha1.Function function */ 3:
-                    message.function = Function.internalBinaryRead(reader, reader.uint32(), options, message.function);
+                case /* parca.query.v1alpha1.Flamegraph callers */ 1:
+                    message.callers = Flamegraph.internalBinaryRead(reader, reader.uint32(), options, message.callers);
                     break;
-                case /* parca.metastore.v1alpha1.Line line */ 4:
-                    message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
+                case /* parca.query.v1alpha1.Flamegraph callees */ 2:
+                    message.callees = Flamegraph.internalBinaryRead(reader, reader.uint32(), options, message.callees);
                     break;
                 default:
                     let u = options.readUnknownField;
@@ -1709,19 +3209,13 @@ class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
         }
         return message;
     }
-    internalBinaryWrite(message: TopNodeMeta, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
-        /* parca.metastore.v1alpha1.Location location = 1; */
-        if (message.location)
-            Location.internalBinaryWrite(message.location, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Mapping mapping = 2; */
-        if (message.mapping)
-            Mapping.internalBinaryWrite(message.mapping, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Function function = 3; */
-        if (message.function)
-            Function.internalBinaryWrite(message.function, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
-        /* parca.metastore.v1alpha1.Line line = 4; */
-        if (message.line)
-            Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+    internalBinaryWrite(message: Sandwich, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.query.v1alpha1.Flamegraph callers = 1; */
+        if (message.callers)
+            Flamegraph.internalBinaryWrite(message.callers, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Flamegraph callees = 2; */
+        if (message.callees)
+            Flamegraph.internalBinaryWrite(message.callees, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1729,9 +3223,9 @@ class TopNodeMeta$Type extends MessageType<TopNodeMeta> {
     }
 }
 /**
- * @generated MessageType for protobuf message parca.query.v1alpha1.TopNodeMeta
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Sandwich
  */
-export const TopNodeMeta = new TopNodeMeta$Type();
+export const Sandwich = new Sandwich$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class Flamegraph$Type extends MessageType<Flamegraph> {
     constructor() {
@@ -1739,11 +3233,12 @@ class Flamegraph$Type extends MessageType<Flamegraph> {
             { no: 1, name: "root", kind: "message", T: () => FlamegraphRootNode },
             { no: 2, name: "total", kind: "scalar", T: 3 /*ScalarType.INT64*/ },
             { no: 3, name: "unit", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "height", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+            { no: 4, name: "height", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 5, name: "trimmed", kind: "scalar", T: 3 /*ScalarType.INT64*/ }
         ]);
     }
     create(value?: PartialMessage<Flamegraph>): Flamegraph {
-        const message = { total: "0", unit: "", height: 0 };
+        const message = { total: "0", unit: "", height: 0, trimmed: "0" };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<Flamegraph>(this, message, value);
@@ -1766,6 +3261,9 @@ class Flamegraph$Type extends MessageType<Flamegraph> {
                 case /* int32 height */ 4:
                     message.height = reader.int32();
                     break;
+                case /* int64 trimmed */ 5:
+                    message.trimmed = reader.int64().toString();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1790,6 +3288,9 @@ class Flamegraph$Type extends MessageType<Flamegraph> {
         /* int32 height = 4; */
         if (message.height !== 0)
             writer.tag(4, WireType.Varint).int32(message.height);
+        /* int64 trimmed = 5; */
+        if (message.trimmed !== "0")
+            writer.tag(5, WireType.Varint).int64(message.trimmed);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1936,11 +3437,13 @@ class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
             { no: 1, name: "location", kind: "message", T: () => Location },
             { no: 2, name: "mapping", kind: "message", T: () => Mapping },
             { no: 3, name: "function", kind: "message", T: () => Function },
-            { no: 4, name: "line", kind: "message", T: () => Line }
+            { no: 4, name: "line", kind: "message", T: () => Line },
+            { no: 5, name: "label", kind: "message", T: () => Label },
+            { no: 6, name: "other", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
     create(value?: PartialMessage<FlamegraphNodeMeta>): FlamegraphNodeMeta {
-        const message = {};
+        const message = { other: false };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<FlamegraphNodeMeta>(this, message, value);
@@ -1963,6 +3466,12 @@ class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
                 case /* parca.metastore.v1alpha1.Line line */ 4:
                     message.line = Line.internalBinaryRead(reader, reader.uint32(), options, message.line);
                     break;
+                case /* parca.profilestore.v1alpha1.Label label */ 5:
+                    message.label = Label.internalBinaryRead(reader, reader.uint32(), options, message.label);
+                    break;
+                case /* bool other */ 6:
+                    message.other = reader.bool();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1987,6 +3496,12 @@ class FlamegraphNodeMeta$Type extends MessageType<FlamegraphNodeMeta> {
         /* parca.metastore.v1alpha1.Line line = 4; */
         if (message.line)
             Line.internalBinaryWrite(message.line, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* parca.profilestore.v1alpha1.Label label = 5; */
+        if (message.label)
+            Label.internalBinaryWrite(message.label, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* bool other = 6; */
+        if (message.other !== false)
+            writer.tag(6, WireType.Varint).bool(message.other);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2003,7 +3518,11 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
         super("parca.query.v1alpha1.QueryResponse", [
             { no: 5, name: "flamegraph", kind: "message", oneof: "report", T: () => Flamegraph },
             { no: 6, name: "pprof", kind: "scalar", oneof: "report", T: 12 /*ScalarType.BYTES*/ },
-            { no: 7, name: "top", kind: "message", oneof: "report", T: () => Top }
+            { no: 7, name: "top", kind: "message", oneof: "report", T: () => Top },
+            { no: 8, name: "callgraph", kind: "message", oneof: "report", T: () => Callgraph },
+            { no: 9, name: "source", kind: "message", oneof: "report", T: () => Source },
+            { no: 10, name: "disassembly", kind: "message", oneof: "report", T: () => Disassembly },
+            { no: 11, name: "sandwich", kind: "message", oneof: "report", T: () => Sandwich }
         ]);
     }
     create(value?: PartialMessage<QueryResponse>): QueryResponse {
@@ -2036,6 +3555,30 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
                         top: Top.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).top)
                     };
                     break;
+                case /* parca.query.v1alpha1.Callgraph callgraph */ 8:
+                    message.report = {
+                        oneofKind: "callgraph",
+                        callgraph: Callgraph.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).callgraph)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Source source */ 9:
+                    message.report = {
+                        oneofKind: "source",
+                        source: Source.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).source)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Disassembly disassembly */ 10:
+                    message.report = {
+                        oneofKind: "disassembly",
+                        disassembly: Disassembly.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).disassembly)
+                    };
+                    break;
+                case /* parca.query.v1alpha1.Sandwich sandwich */ 11:
+                    message.report = {
+                        oneofKind: "sandwich",
+                        sandwich: Sandwich.internalBinaryRead(reader, reader.uint32(), options, (message.report as any).sandwich)
+                    };
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2057,6 +3600,18 @@ class QueryResponse$Type extends MessageType<QueryResponse> {
         /* parca.query.v1alpha1.Top top = 7; */
         if (message.report.oneofKind === "top")
             Top.internalBinaryWrite(message.report.top, writer.tag(7, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Callgraph callgraph = 8; */
+        if (message.report.oneofKind === "callgraph")
+            Callgraph.internalBinaryWrite(message.report.callgraph, writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Source source = 9; */
+        if (message.report.oneofKind === "source")
+            Source.internalBinaryWrite(message.report.source, writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Disassembly disassembly = 10; */
+        if (message.report.oneofKind === "disassembly")
+            Disassembly.internalBinaryWrite(message.report.disassembly, writer.tag(10, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.Sandwich sandwich = 11; */
+        if (message.report.oneofKind === "sandwich")
+            Sandwich.internalBinaryWrite(message.report.sandwich, writer.tag(11, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2131,19 +3686,40 @@ export const SeriesRequest = new SeriesRequest$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class SeriesResponse$Type extends MessageType<SeriesResponse> {
     constructor() {
-        super("parca.query.v1alpha1.SeriesResponse", []);
+        super("parca.query.v1alpha1.SeriesResponse", [
+            { no: 1, name: "series", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => Series }
+        ]);
     }
     create(value?: PartialMessage<SeriesResponse>): SeriesResponse {
-        const message = {};
+        const message = { series: [] };
         globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
         if (value !== undefined)
             reflectionMergePartial<SeriesResponse>(this, message, value);
         return message;
     }
     internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SeriesResponse): SeriesResponse {
-        return target ?? this.create();
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated parca.query.v1alpha1.Series series */ 1:
+                    message.series.push(Series.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
     }
     internalBinaryWrite(message: SeriesResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated parca.query.v1alpha1.Series series = 1; */
+        for (let i = 0; i < message.series.length; i++)
+            Series.internalBinaryWrite(message.series[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2155,6 +3731,60 @@ class SeriesResponse$Type extends MessageType<SeriesResponse> {
  */
 export const SeriesResponse = new SeriesResponse$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class Series$Type extends MessageType<Series> {
+    constructor() {
+        super("parca.query.v1alpha1.Series", [
+            { no: 1, name: "labelset", kind: "message", T: () => LabelSet },
+            { no: 2, name: "profile_type", kind: "message", T: () => ProfileType }
+        ]);
+    }
+    create(value?: PartialMessage<Series>): Series {
+        const message = {};
+        globalThis.Object.defineProperty(message, MESSAGE_TYPE, { enumerable: false, value: this });
+        if (value !== undefined)
+            reflectionMergePartial<Series>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Series): Series {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* parca.profilestore.v1alpha1.LabelSet labelset */ 1:
+                    message.labelset = LabelSet.internalBinaryRead(reader, reader.uint32(), options, message.labelset);
+                    break;
+                case /* parca.query.v1alpha1.ProfileType profile_type */ 2:
+                    message.profileType = ProfileType.internalBinaryRead(reader, reader.uint32(), options, message.profileType);
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Series, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* parca.profilestore.v1alpha1.LabelSet labelset = 1; */
+        if (message.labelset)
+            LabelSet.internalBinaryWrite(message.labelset, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* parca.query.v1alpha1.ProfileType profile_type = 2; */
+        if (message.profileType)
+            ProfileType.internalBinaryWrite(message.profileType, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message parca.query.v1alpha1.Series
+ */
+export const Series = new Series$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class LabelsRequest$Type extends MessageType<LabelsRequest> {
     constructor() {
         super("parca.query.v1alpha1.LabelsRequest", [